@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/render"
+)
+
+// achievement is a goal the player works towards. progress reports how far
+// along the player is and how far they need to get.
+type achievement struct {
+	ID          string
+	Title       string
+	Description string
+	Reward      int // Money awarded on unlocking
+	progress    func(cfg *Config) (have, need int)
+}
+
+// kantoStarterLines are the evolution lines of the Kanto starters.
+var kantoStarterLines = [][]string{
+	{"bulbasaur", "ivysaur", "venusaur"},
+	{"charmander", "charmeleon", "charizard"},
+	{"squirtle", "wartortle", "blastoise"},
+}
+
+var achievements = []achievement{
+	{
+		ID: "first-catch", Title: "First catch", Description: "Catch your first Pokémon.", Reward: 500,
+		progress: func(cfg *Config) (int, int) { return len(cfg.PokemonCaught), 1 },
+	},
+	{
+		ID: "kanto-starters", Title: "Kanto starters", Description: "Own a Bulbasaur, Charmander and Squirtle, or their evolutions.", Reward: 2000,
+		progress: func(cfg *Config) (int, int) {
+			owned := 0
+			for _, line := range kantoStarterLines {
+				if cfg.ownsAny(line...) {
+					owned++
+				}
+			}
+			return owned, len(kantoStarterLines)
+		},
+	},
+	{
+		ID: "full-party", Title: "Full party", Description: "Have six Pokémon in your party.", Reward: 1000,
+		progress: func(cfg *Config) (int, int) { return len(cfg.Party), partySize },
+	},
+	{
+		ID: "collector", Title: "Collector", Description: "Own 25 different species.", Reward: 3000,
+		progress: func(cfg *Config) (int, int) {
+			species := make(map[string]bool)
+			for _, pokemon := range cfg.PokemonCaught {
+				species[pokemon.Species] = true
+			}
+			return len(species), 25
+		},
+	},
+	{
+		ID: "explorer", Title: "Explorer", Description: "Explore 10 location areas.", Reward: 1000,
+		progress: func(cfg *Config) (int, int) { return len(cfg.ExploredAreas), 10 },
+	},
+	{
+		ID: "globetrotter", Title: "Globetrotter", Description: "Explore 50 location areas.", Reward: 5000,
+		progress: func(cfg *Config) (int, int) { return len(cfg.ExploredAreas), 50 },
+	},
+	{
+		ID: "battler", Title: "Battler", Description: "Win 10 battles against wild Pokémon.", Reward: 1500,
+		progress: func(cfg *Config) (int, int) { return cfg.Trainer.BattlesWon, 10 },
+	},
+	{
+		ID: "veteran", Title: "Veteran", Description: "Raise a Pokémon to level 50.", Reward: 5000,
+		progress: func(cfg *Config) (int, int) {
+			highest := 0
+			for _, pokemon := range cfg.PokemonCaught {
+				highest = max(highest, pokemon.Level)
+			}
+			return highest, 50
+		},
+	},
+	{
+		ID: "shiny-hunter", Title: "Shiny hunter", Description: "Catch a shiny Pokémon.", Reward: 5000,
+		progress: func(cfg *Config) (int, int) {
+			for _, pokemon := range cfg.PokemonCaught {
+				if pokemon.Shiny {
+					return 1, 1
+				}
+			}
+			return 0, 1
+		},
+	},
+}
+
+// ownsAny reports whether the player owns a Pokémon of any of the species.
+func (cfg *Config) ownsAny(species ...string) bool {
+	for _, pokemon := range cfg.PokemonCaught {
+		for _, name := range species {
+			if pokemon.Species == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkAchievements unlocks every achievement the player has newly
+// completed and pays out its reward.
+func (cfg *Config) checkAchievements() {
+	for _, a := range achievements {
+		if _, unlocked := cfg.Trainer.Achievements[a.ID]; unlocked {
+			continue
+		}
+		if have, need := a.progress(cfg); have < need {
+			continue
+		}
+		if cfg.Trainer.Achievements == nil {
+			cfg.Trainer.Achievements = make(map[string]time.Time)
+		}
+		cfg.Trainer.Achievements[a.ID] = time.Now()
+		cfg.Trainer.Money += a.Reward
+		cfg.notef("Achievement unlocked: %s! You received ₽%d.\n", a.Title, a.Reward)
+	}
+}
+
+type achievementRecord struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Progress    int        `json:"progress"`
+	Goal        int        `json:"goal"`
+	UnlockedAt  *time.Time `json:"unlocked_at"`
+}
+
+func commandAchievements(cfg *Config, commands []string) error {
+	records := make([]achievementRecord, 0, len(achievements))
+	for _, a := range achievements {
+		have, need := a.progress(cfg)
+		record := achievementRecord{ID: a.ID, Title: a.Title, Description: a.Description, Progress: min(have, need), Goal: need}
+		if at, unlocked := cfg.Trainer.Achievements[a.ID]; unlocked {
+			record.UnlockedAt = &at
+			record.Progress = need
+		}
+		records = append(records, record)
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Achievements (%d/%d):\n", len(cfg.Trainer.Achievements), len(achievements))
+	table := render.NewTable("", cfg.style.Bold("ACHIEVEMENT"), cfg.style.Bold("PROGRESS"), cfg.style.Bold("GOAL"))
+	table.Indent = "  "
+	for _, record := range records {
+		mark := " "
+		if record.UnlockedAt != nil {
+			mark = "✓"
+		}
+		table.AddRow(mark, record.Title, fmt.Sprintf("%d/%d", record.Progress, record.Goal), record.Description)
+	}
+	return table.Write(cfg.out)
+}
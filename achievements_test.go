@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func TestKantoStartersAchievement(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 5, Name: "charmeleon"})
+
+	cfg.checkAchievements()
+	if _, unlocked := cfg.Trainer.Achievements["kanto-starters"]; unlocked {
+		t.Fatal("Expected kanto-starters to need all three lines")
+	}
+	if _, unlocked := cfg.Trainer.Achievements["first-catch"]; !unlocked || cfg.Trainer.Money != startingMoney+500 {
+		t.Errorf("Expected first-catch to pay out, have ₽%d", cfg.Trainer.Money)
+	}
+
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 7, Name: "squirtle"})
+	if err := executeCommand(cfg, "party"); err != nil {
+		t.Fatalf("party returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Achievement unlocked: Kanto starters!") {
+		t.Errorf("Expected kanto-starters to unlock after a command, got %q", out.String())
+	}
+
+	// Unlocking only happens once.
+	money := cfg.Trainer.Money
+	cfg.checkAchievements()
+	if cfg.Trainer.Money != money {
+		t.Errorf("Expected no second reward, money went from %d to %d", money, cfg.Trainer.Money)
+	}
+
+	out.Reset()
+	if err := commandAchievements(cfg, nil); err != nil {
+		t.Fatalf("achievements returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "✓  Kanto starters") || !strings.Contains(out.String(), "0/10") {
+		t.Errorf("Unexpected achievements output: %q", out.String())
+	}
+}
+
+func TestExplorerProgress(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.enterArea("route-1")
+	cfg.enterArea("route-2")
+	cfg.enterArea("route-1")
+
+	if len(cfg.ExploredAreas) != 2 {
+		t.Errorf("Expected 2 explored areas, got %v", cfg.ExploredAreas)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseArgs splits command arguments into positional arguments and --flag
+// values. Flags named in boolFlags take no value and are recorded as "true";
+// every other flag consumes the following argument or uses --flag=value.
+func parseArgs(args []string, boolFlags ...string) ([]string, map[string]string, error) {
+	var positional []string
+	flags := make(map[string]string)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") || arg == "--" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name := strings.TrimPrefix(arg, "--")
+		if key, value, found := strings.Cut(name, "="); found {
+			flags[key] = value
+			continue
+		}
+
+		isBool := false
+		for _, boolFlag := range boolFlags {
+			if name == boolFlag {
+				isBool = true
+				break
+			}
+		}
+		if isBool {
+			flags[name] = "true"
+			continue
+		}
+
+		if i+1 >= len(args) {
+			return nil, nil, fmt.Errorf("flag --%s requires a value", name)
+		}
+		flags[name] = args[i+1]
+		i++
+	}
+
+	return positional, flags, nil
+}
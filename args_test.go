@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		boolFlags  []string
+		positional []string
+		flags      map[string]string
+		expectErr  bool
+	}{
+		{
+			name:       "Positional only",
+			args:       []string{"pallet-town-area"},
+			positional: []string{"pallet-town-area"},
+			flags:      map[string]string{},
+		},
+		{
+			name:       "Flag with separate value",
+			args:       []string{"viridian-forest-area", "--version", "red"},
+			positional: []string{"viridian-forest-area"},
+			flags:      map[string]string{"version": "red"},
+		},
+		{
+			name:       "Flag with equals value",
+			args:       []string{"--version=blue", "viridian-forest-area"},
+			positional: []string{"viridian-forest-area"},
+			flags:      map[string]string{"version": "blue"},
+		},
+		{
+			name:       "Boolean flag",
+			args:       []string{"--missing", "kanto"},
+			boolFlags:  []string{"missing"},
+			positional: []string{"kanto"},
+			flags:      map[string]string{"missing": "true"},
+		},
+		{
+			name:      "Missing value",
+			args:      []string{"viridian-forest-area", "--version"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positional, flags, err := parseArgs(tt.args, tt.boolFlags...)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(positional, tt.positional) {
+				t.Errorf("Expected positional %v, got %v", tt.positional, positional)
+			}
+			if !reflect.DeepEqual(flags, tt.flags) {
+				t.Errorf("Expected flags %v, got %v", tt.flags, flags)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
+)
+
+// leaderPrizeBase is what a gym leader pays per level of their strongest
+// Pokémon, as in Red and Blue.
+const leaderPrizeBase = 99
+
+// badge is a Kanto gym badge. With no gyms to challenge, a badge is earned
+// by raising a Pokémon of the gym's type to the level of the leader's
+// strongest Pokémon.
+type badge struct {
+	ID     string
+	Name   string
+	Leader string
+	Type   string
+	Level  int
+}
+
+var badges = []badge{
+	{ID: "boulder", Name: "Boulder Badge", Leader: "Brock", Type: "rock", Level: 14},
+	{ID: "cascade", Name: "Cascade Badge", Leader: "Misty", Type: "water", Level: 21},
+	{ID: "thunder", Name: "Thunder Badge", Leader: "Lt. Surge", Type: "electric", Level: 24},
+	{ID: "rainbow", Name: "Rainbow Badge", Leader: "Erika", Type: "grass", Level: 29},
+	{ID: "soul", Name: "Soul Badge", Leader: "Koga", Type: "poison", Level: 43},
+	{ID: "marsh", Name: "Marsh Badge", Leader: "Sabrina", Type: "psychic", Level: 43},
+	{ID: "volcano", Name: "Volcano Badge", Leader: "Blaine", Type: "fire", Level: 47},
+	{ID: "earth", Name: "Earth Badge", Leader: "Giovanni", Type: "ground", Level: 50},
+}
+
+// prize is the money paid out with the badge.
+func (b badge) prize() int {
+	return leaderPrizeBase * b.Level
+}
+
+// bestOfType returns the highest level among the player's Pokémon of a type.
+func (cfg *Config) bestOfType(typeName string) int {
+	best := 0
+	for _, pokemon := range cfg.PokemonCaught {
+		if slices.ContainsFunc(pokemon.Info.Types, func(t pokeapi.PokemonType) bool { return t.Type.Name == typeName }) {
+			best = max(best, pokemon.Level)
+		}
+	}
+	return best
+}
+
+// checkBadges awards every badge the player has newly earned along with the
+// leader's prize money. Badges are kept even if the Pokémon that earned them
+// is later released.
+func (cfg *Config) checkBadges() {
+	for _, b := range badges {
+		if _, earned := cfg.Trainer.Badges[b.ID]; earned || cfg.bestOfType(b.Type) < b.Level {
+			continue
+		}
+		if cfg.Trainer.Badges == nil {
+			cfg.Trainer.Badges = make(map[string]time.Time)
+		}
+		cfg.Trainer.Badges[b.ID] = time.Now()
+		cfg.Trainer.Money += b.prize()
+		cfg.notef("%s recognises your %s Pokémon. You earned the %s and ₽%d!\n", b.Leader, b.Type, b.Name, b.prize())
+	}
+}
+
+// earnedBadges returns the names of the badges the player has, in gym order.
+func (cfg *Config) earnedBadges() []string {
+	names := []string{}
+	for _, b := range badges {
+		if _, earned := cfg.Trainer.Badges[b.ID]; earned {
+			names = append(names, b.Name)
+		}
+	}
+	return names
+}
+
+type badgeRecord struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Leader   string     `json:"leader"`
+	Type     string     `json:"type"`
+	Level    int        `json:"level"`
+	Best     int        `json:"best"`
+	EarnedAt *time.Time `json:"earned_at"`
+}
+
+func commandBadges(cfg *Config, commands []string) error {
+	records := make([]badgeRecord, 0, len(badges))
+	for _, b := range badges {
+		record := badgeRecord{ID: b.ID, Name: b.Name, Leader: b.Leader, Type: b.Type, Level: b.Level, Best: cfg.bestOfType(b.Type)}
+		if at, earned := cfg.Trainer.Badges[b.ID]; earned {
+			record.EarnedAt = &at
+		}
+		records = append(records, record)
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Badges (%d/%d):\n", len(cfg.earnedBadges()), len(badges))
+	table := render.NewTable("", cfg.style.Bold("BADGE"), cfg.style.Bold("LEADER"), cfg.style.Bold("NEEDS"), cfg.style.Bold("BEST"))
+	table.Indent = "  "
+	for _, record := range records {
+		mark := " "
+		if record.EarnedAt != nil {
+			mark = "✓"
+		}
+		best := "-"
+		if record.Best > 0 {
+			best = fmt.Sprintf("Lv. %d", record.Best)
+		}
+		table.AddRow(mark, record.Name, record.Leader, fmt.Sprintf("%s Pokémon at Lv. %d", record.Type, record.Level), best)
+	}
+	return table.Write(cfg.out)
+}
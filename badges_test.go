@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// typed returns Pokémon info for a species of a single type.
+func typed(id int, name, typeName string) pokeapi.PokemonInfo {
+	info := pokeapi.PokemonInfo{ID: id, Name: name}
+	var t pokeapi.PokemonType
+	t.Slot = 1
+	t.Type.Name = typeName
+	info.Types = []pokeapi.PokemonType{t}
+	return info
+}
+
+func TestBadgeNeedsLevelOfGymType(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	geodude := givePokemon(cfg, typed(74, "geodude", "rock"))
+	geodude.Level = 13
+	pikachu := givePokemon(cfg, typed(25, "pikachu", "electric"))
+	pikachu.Level = 30
+
+	cfg.checkBadges()
+	if _, earned := cfg.Trainer.Badges["boulder"]; earned {
+		t.Fatal("Expected the Boulder Badge to need a rock Pokémon at level 14")
+	}
+	if _, earned := cfg.Trainer.Badges["thunder"]; !earned || cfg.Trainer.Money != startingMoney+24*leaderPrizeBase {
+		t.Errorf("Expected the Thunder Badge and its prize, have %v and ₽%d", cfg.Trainer.Badges, cfg.Trainer.Money)
+	}
+
+	geodude.Level = 14
+	if err := executeCommand(cfg, "party"); err != nil {
+		t.Fatalf("party returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "You earned the Boulder Badge") {
+		t.Errorf("Expected the Boulder Badge after a command, got %q", out.String())
+	}
+
+	// Badges stay earned.
+	cfg.removePokemon(geodude)
+	money := cfg.Trainer.Money
+	cfg.checkBadges()
+	if _, earned := cfg.Trainer.Badges["boulder"]; !earned || cfg.Trainer.Money != money {
+		t.Error("Expected the Boulder Badge to be kept without a second prize")
+	}
+
+	out.Reset()
+	if err := commandProfile(cfg, nil); err != nil {
+		t.Fatalf("profile returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Badges: 2/8 (Boulder Badge, Thunder Badge)") {
+		t.Errorf("Expected the badges in the profile, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := commandBadges(cfg, nil); err != nil {
+		t.Fatalf("badges returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Badges (2/8):") || !strings.Contains(out.String(), "✓  Thunder Badge") {
+		t.Errorf("Unexpected badges output: %q", out.String())
+	}
+}
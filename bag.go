@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// Inventory holds the items the player is carrying, keyed by item name.
+type Inventory map[string]int
+
+// ballCatchBonus lists the Poké Balls that can be thrown and their catch
+// rate multiplier relative to a regular Poké Ball.
+var ballCatchBonus = map[string]float64{
+	"poke-ball":   1,
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": 255,
+}
+
+// potionHealing lists the potions and how much HP each restores, as in Red
+// and Blue. A max-potion restores all of it.
+var potionHealing = map[string]int{
+	"potion":       20,
+	"super-potion": 50,
+	"hyper-potion": 200,
+	"max-potion":   math.MaxInt,
+}
+
+func newStarterInventory() Inventory {
+	return Inventory{
+		"poke-ball":   10,
+		"great-ball":  5,
+		"ultra-ball":  3,
+		"master-ball": 1,
+	}
+}
+
+func (inv Inventory) Add(item string, quantity int) {
+	inv[item] += quantity
+}
+
+// Remove takes a single item out of the inventory. It reports false if the
+// player doesn't have any left.
+func (inv Inventory) Remove(item string) bool {
+	if inv[item] <= 0 {
+		return false
+	}
+	inv[item]--
+	if inv[item] == 0 {
+		delete(inv, item)
+	}
+	return true
+}
+
+// Names returns the items in the inventory sorted alphabetically.
+func (inv Inventory) Names() []string {
+	names := make([]string, 0, len(inv))
+	for name := range inv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func commandBag(cfg *Config, commands []string) error {
+	records := []bagRecord{}
+	for _, name := range cfg.Bag.Names() {
+		records = append(records, bagRecord{Item: name, Quantity: cfg.Bag[name]})
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	if len(cfg.Bag) == 0 {
+		fmt.Fprintln(cfg.out, "Your bag is empty.")
+		return nil
+	}
+
+	fmt.Fprintln(cfg.out, "Your bag:")
+	for _, name := range cfg.Bag.Names() {
+		fmt.Fprintf(cfg.out, " - %s x%d\n", name, cfg.Bag[name])
+	}
+	return nil
+}
+
+func commandItem(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("item command requires an item name as an argument")
+	}
+
+	itemName := commands[0]
+	item, err := pokeapi.GetItem(cfg.cache, itemName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch item info for '%s': %v", itemName, err)
+	}
+	if handled, err := cfg.emit(item); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Name: %s\n", item.Name)
+	fmt.Fprintf(cfg.out, "Category: %s\n", item.Category.Name)
+	fmt.Fprintf(cfg.out, "Cost: %d\n", item.Cost)
+	if effect := item.ShortEffect(); effect != "" {
+		fmt.Fprintf(cfg.out, "Effect: %s\n", effect)
+	}
+	fmt.Fprintf(cfg.out, "In bag: %d\n", cfg.Bag[item.Name])
+	return nil
+}
+
+func commandUse(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("use command requires an item name as an argument")
+	}
+
+	itemName := commands[0]
+	if cfg.Bag[itemName] <= 0 {
+		return fmt.Errorf("you don't have any %s", itemName)
+	}
+
+	if _, isBall := ballCatchBonus[itemName]; isBall {
+		if len(commands) < 2 {
+			return fmt.Errorf("using %s requires a Pokémon name as a target", itemName)
+		}
+		return throwBall(cfg, commands[1], itemName)
+	}
+	if healing, isPotion := potionHealing[itemName]; isPotion {
+		return cfg.usePotion(itemName, healing)
+	}
+
+	return fmt.Errorf("%s can't be used right now", itemName)
+}
+
+// usePotion heals the player's Pokémon in battle, which costs the player
+// their turn. Outside battle Pokémon are always at full health.
+func (cfg *Config) usePotion(potion string, healing int) error {
+	if cfg.Wild == nil || cfg.Wild.Battle == nil {
+		return fmt.Errorf("%s can only be used on a Pokémon hurt in battle", potion)
+	}
+	fighter := cfg.Wild.Battle.Player
+	if fighter.HP >= fighter.Stats.HP {
+		return fmt.Errorf("%s is already at full health", fighter.Name)
+	}
+
+	cfg.Bag.Remove(potion)
+	restored := min(healing, fighter.Stats.HP-fighter.HP)
+	fighter.HP += restored
+	cfg.notef("%s's HP was restored by %d.\n", fighter.Name, restored)
+	return cfg.printBattle(cfg.Wild.Battle.WildAttack())
+}
+
+// throwBall attempts to catch pokemonName with the given ball, consuming the
+// ball from the bag whether or not the catch succeeds.
+func throwBall(cfg *Config, pokemonName, ball string) error {
+	bonus, isBall := ballCatchBonus[ball]
+	if !isBall {
+		return fmt.Errorf("%s is not a Poké Ball", ball)
+	}
+
+	// Only the wild Pokémon the player is facing can be caught.
+	if cfg.Wild == nil {
+		return fmt.Errorf("there's no wild Pokémon to catch; use encounter to look for one")
+	}
+	if pokemonName != cfg.Wild.Pokemon {
+		corrected, _ := cfg.correctName(pokemonName, []string{cfg.Wild.Pokemon})
+		if corrected == "" {
+			return fmt.Errorf("there's no wild %s here, only a wild %s", pokemonName, cfg.Wild.Pokemon)
+		}
+		cfg.notef("Assuming you meant %s.\n", corrected)
+		pokemonName = corrected
+	}
+
+	if cfg.Bag[ball] <= 0 {
+		return fmt.Errorf("you don't have any %s left", ball)
+	}
+
+	pokeInfo, err := pokeapi.GetPokemonInfo(cfg.cache, pokemonName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", pokemonName, err)
+	}
+
+	species, err := pokeapi.GetPokemonSpecies(cfg.cache, pokeInfo.SpeciesName())
+	if err != nil {
+		return fmt.Errorf("failed to fetch species info for '%s': %v", pokemonName, err)
+	}
+
+	// Roll what the Pokémon would be like before using up the ball, so a
+	// failed lookup doesn't waste it.
+	location := cfg.Wild.Area
+	if location == "" {
+		location = cfg.CurrentArea
+	}
+	pokemon := &CaughtPokemon{
+		ID:       cfg.newPokemonID(),
+		Species:  pokemonName,
+		Level:    cfg.Wild.Level,
+		Location: location,
+		Info:     *pokeInfo,
+	}
+	if err := cfg.rollTraits(pokemon, species); err != nil {
+		return err
+	}
+
+	cfg.Bag.Remove(ball)
+	cfg.notef("Throwing a %s at %s...\n", ball, pokemonName)
+	cfg.notef("%s %s (capture rate %d).\n", pokemonName, catchDifficulty(species.CaptureRate), species.CaptureRate)
+
+	attempt := cfg.Wild.catchAttempt(pokeInfo, species.CaptureRate, bonus)
+	wobbles, caught := attempt.throw(cfg.rng.IntN)
+	if wobbles == 1 {
+		cfg.notef("The ball wobbled... 1 time\n")
+	} else if wobbles > 1 {
+		cfg.notef("The ball wobbled... %d times\n", wobbles)
+	}
+	if caught {
+		pokemon.CaughtAt = time.Now()
+		cfg.notef("%s was caught!\n", pokemonName)
+		if pokemon.Shiny {
+			cfg.notef("Wow, it's a shiny %s!\n", pokemonName)
+		}
+		cfg.notef("%s was sent to %s.\n", pokemonName, cfg.addCaught(pokemon))
+		cfg.notef("You may now inspect it with the inspect command.\n")
+		wild := cfg.Wild
+		cfg.Wild = nil
+		if err := cfg.setExperience(pokemon, species); err != nil {
+			return err
+		}
+		return cfg.rewardFighter(wild, false)
+	} else {
+		cfg.notef("%s escaped the %s!\n", pokemonName, ball)
+		// In battle a failed throw costs the player their turn.
+		if cfg.Wild.Battle != nil {
+			return cfg.printBattle(cfg.Wild.Battle.WildAttack())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// seedPokemon stores a minimal Pokémon response and its species in the
+// config's cache so commands can run without network access.
+func seedPokemon(cfg *Config, name string, baseExperience int) {
+	body := fmt.Sprintf(`{"id": 25, "name": %q, "height": 4, "weight": 60, "base_experience": %d, "stats": [], "types": []}`,
+		name, baseExperience)
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/"+name, []byte(body))
+	seedSpecies(cfg, name, 45)
+}
+
+// meetWild puts a wild Pokémon in front of the player so it can be caught.
+func meetWild(cfg *Config, name string) {
+	cfg.Wild = &wildEncounter{Pokemon: name, Level: 5}
+}
+
+func seedSpecies(cfg *Config, name string, captureRate int) {
+	body := fmt.Sprintf(`{"id": 25, "name": %q, "capture_rate": %d}`, name, captureRate)
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon-species/"+name, []byte(body))
+}
+
+func TestInventoryAddRemove(t *testing.T) {
+	inv := Inventory{}
+	inv.Add("poke-ball", 2)
+
+	if !inv.Remove("poke-ball") {
+		t.Fatal("Expected to remove a poke-ball")
+	}
+	if inv["poke-ball"] != 1 {
+		t.Errorf("Expected 1 poke-ball left, got %d", inv["poke-ball"])
+	}
+	if !inv.Remove("poke-ball") {
+		t.Fatal("Expected to remove the last poke-ball")
+	}
+	if _, exists := inv["poke-ball"]; exists {
+		t.Error("Empty items should be removed from the inventory")
+	}
+	if inv.Remove("poke-ball") {
+		t.Error("Removing from an empty stack should fail")
+	}
+}
+
+func TestInventoryNamesSorted(t *testing.T) {
+	inv := newStarterInventory()
+	names := inv.Names()
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("Names not sorted: %v", names)
+		}
+	}
+}
+
+func TestUseMasterBallCatches(t *testing.T) {
+	cfg := createTestConfig()
+	seedPokemon(cfg, "pikachu", 112)
+	meetWild(cfg, "pikachu")
+
+	if err := commandUse(cfg, []string{"master-ball", "pikachu"}); err != nil {
+		t.Fatalf("use master-ball returned an error: %v", err)
+	}
+
+	if caught := hasCaught(cfg, "pikachu"); !caught {
+		t.Error("A master-ball should always catch")
+	}
+	if cfg.Bag["master-ball"] != 0 {
+		t.Errorf("The master-ball should have been consumed, %d left", cfg.Bag["master-ball"])
+	}
+}
+
+func TestCatchConsumesPokeBall(t *testing.T) {
+	cfg := createTestConfig()
+	seedPokemon(cfg, "rattata", 51)
+	meetWild(cfg, "rattata")
+	before := cfg.Bag["poke-ball"]
+
+	if err := commandCatch(cfg, []string{"rattata"}); err != nil {
+		t.Fatalf("catch returned an error: %v", err)
+	}
+
+	if cfg.Bag["poke-ball"] != before-1 {
+		t.Errorf("Expected %d poke-balls after throwing one, got %d", before-1, cfg.Bag["poke-ball"])
+	}
+}
+
+func TestCatchWithoutBalls(t *testing.T) {
+	cfg := createTestConfig()
+	seedPokemon(cfg, "rattata", 51)
+	meetWild(cfg, "rattata")
+	delete(cfg.Bag, "poke-ball")
+
+	if err := commandCatch(cfg, []string{"rattata"}); err == nil || !strings.Contains(err.Error(), "don't have any") {
+		t.Errorf("Expected an error catching without balls, got %v", err)
+	}
+	if caught := hasCaught(cfg, "rattata"); caught {
+		t.Error("Should not be able to catch without any balls")
+	}
+}
+
+func TestUseRequiresTarget(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandUse(cfg, []string{"poke-ball"}); err == nil {
+		t.Error("Expected an error when throwing a ball without a target")
+	}
+	if err := commandUse(cfg, nil); err == nil {
+		t.Error("Expected an error when no item is given")
+	}
+	if err := commandUse(cfg, []string{"potion"}); err == nil {
+		t.Error("Expected an error when using an item the bag doesn't hold")
+	}
+}
+
+func TestUsePotionInBattle(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.reseed(1)
+	seedPikachu(t, cfg)
+	seedArea(cfg)
+	cfg.CurrentArea = "route-1"
+	cfg.Bag.Add("potion", 2)
+
+	if err := commandUse(cfg, []string{"potion"}); err == nil {
+		t.Error("Expected an error using a potion outside battle")
+	}
+	if err := commandEncounter(cfg, nil); err != nil {
+		t.Fatalf("encounter returned an error: %v", err)
+	}
+	if err := commandUse(cfg, []string{"potion"}); err == nil {
+		t.Error("Expected an error using a potion at full health")
+	}
+	if cfg.Bag["potion"] != 2 {
+		t.Fatalf("Potions shouldn't be used up by failed attempts, have %d", cfg.Bag["potion"])
+	}
+
+	cfg.Wild.Battle.Player.HP = 1
+	if err := commandUse(cfg, []string{"potion"}); err != nil {
+		t.Fatalf("use potion returned an error: %v", err)
+	}
+	// pikachu has 18 HP at level 5, so the potion's 20 HP is capped.
+	if !strings.Contains(out.String(), "pikachu's HP was restored by 17.") || cfg.Bag["potion"] != 1 {
+		t.Errorf("Expected the potion to heal pikachu fully, got %q with %d potions left", out.String(), cfg.Bag["potion"])
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
+)
+
+// defaultLevel is the level given to Pokémon caught before levels were
+// tracked.
+const defaultLevel = 10
+
+const (
+	// maxMoves is how many moves a Pokémon can use in battle.
+	maxMoves = 4
+	// maxMoveLookups bounds how many learned moves are fetched while looking
+	// for damaging ones, since each is a separate request.
+	maxMoveLookups = 8
+)
+
+// newCombatant prepares a Pokémon for battle with its stats at level and
+// the most recent damaging moves it has learned by then.
+func (cfg *Config) newCombatant(pokemon pokeapi.PokemonInfo, level int, stats battle.Stats) (*battle.Combatant, error) {
+	learnable, err := pokeapi.GetPokemonMoves(cfg.cache, pokemon.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch moves for '%s': %v", pokemon.Name, err)
+	}
+	var moves []battle.Move
+	for i, name := range pokeapi.LevelUpMoves(learnable, level) {
+		if len(moves) == maxMoves || i == maxMoveLookups {
+			break
+		}
+		move, err := pokeapi.GetMove(cfg.cache, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch move '%s': %v", name, err)
+		}
+		if move.Power == nil || *move.Power <= 0 {
+			continue
+		}
+		battleMove := battle.Move{
+			Name:        move.Name,
+			Type:        move.Type.Name,
+			DamageClass: move.DamageClass.Name,
+			Power:       *move.Power,
+			Priority:    move.Priority,
+		}
+		if move.Accuracy != nil {
+			battleMove.Accuracy = *move.Accuracy
+		}
+		moves = append(moves, battleMove)
+	}
+
+	return battle.NewCombatant(pokemon.Name, level, typeNames(pokemon), stats, moves), nil
+}
+
+// typeChart fetches the damage multipliers of every move type the
+// combatants can use.
+func (cfg *Config) typeChart(combatants ...*battle.Combatant) (battle.TypeChart, error) {
+	chart := make(battle.TypeChart)
+	for _, combatant := range combatants {
+		for _, move := range combatant.Moves {
+			if _, done := chart[move.Type]; done || move.Type == "" {
+				continue
+			}
+			moveType, err := pokeapi.GetType(cfg.cache, move.Type)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch type '%s': %v", move.Type, err)
+			}
+			chart[move.Type] = moveType.Multipliers()
+		}
+	}
+	return chart, nil
+}
+
+// leadPokemon returns the Pokémon in the first party slot, which fights
+// for the player.
+func (cfg *Config) leadPokemon() (*CaughtPokemon, bool) {
+	if len(cfg.Party) == 0 {
+		return nil, false
+	}
+	return cfg.PokemonCaught[cfg.Party[0]], true
+}
+
+// startBattle sends out the player's lead Pokémon against the wild one. The
+// player can still try to catch the wild Pokémon without a battle when they
+// have no Pokémon yet.
+func (cfg *Config) startBattle() error {
+	lead, ok := cfg.leadPokemon()
+	if !ok {
+		cfg.notef("You have no Pokémon to battle with, but you can still try to catch it.\n")
+		return nil
+	}
+
+	player, err := cfg.newCombatant(lead.Info, lead.Level, lead.Stats())
+	if err != nil {
+		return err
+	}
+	chart, err := cfg.typeChart(player, cfg.Wild.Foe)
+	if err != nil {
+		return err
+	}
+	player.Name = lead.Name()
+	cfg.Wild.Battle = battle.New(player, cfg.Wild.Foe, chart, cfg.rng)
+	cfg.Wild.Fighter = lead.ID
+	cfg.notef("Go! %s!\n", player.Name)
+	cfg.notef("Use fight <move> to attack, catch to throw a ball, or run to flee.\n")
+	return nil
+}
+
+func commandFight(cfg *Config, commands []string) error {
+	if cfg.Wild == nil {
+		cfg.notef("There's no wild Pokémon to fight. Use encounter to look for one.\n")
+		return nil
+	}
+	b := cfg.Wild.Battle
+	if b == nil {
+		cfg.notef("You have no Pokémon to fight with. Catch one first!\n")
+		return nil
+	}
+
+	if len(commands) == 0 {
+		records := make([]moveRecord, 0, len(b.Player.Moves))
+		for i, move := range b.Player.Moves {
+			records = append(records, moveRecord{Number: i + 1, Name: move.Name, Type: move.Type, Power: move.Power, Accuracy: move.Accuracy})
+		}
+		if handled, err := cfg.emit(records); handled {
+			return err
+		}
+
+		fmt.Fprintf(cfg.out, "%s's moves:\n", b.Player.Name)
+		table := render.NewTable(cfg.style.Bold("#"), cfg.style.Bold("MOVE"), cfg.style.Bold("TYPE"), cfg.style.Bold("POWER"), cfg.style.Bold("ACCURACY"))
+		table.Indent = "  "
+		for _, record := range records {
+			accuracy := "-"
+			if record.Accuracy > 0 {
+				accuracy = fmt.Sprintf("%d%%", record.Accuracy)
+			}
+			table.AddRow(fmt.Sprint(record.Number), record.Name, cfg.style.Type(record.Type), fmt.Sprint(record.Power), accuracy)
+		}
+		return table.Write(cfg.out)
+	}
+
+	move, ok := b.Player.FindMove(commands[0])
+	if !ok {
+		return fmt.Errorf("%s doesn't know '%s'; use fight to list its moves", b.Player.Name, commands[0])
+	}
+	return cfg.printBattle(b.Turn(move))
+}
+
+func commandRun(cfg *Config, commands []string) error {
+	if cfg.Wild == nil {
+		cfg.notef("There's nothing to run from.\n")
+		return nil
+	}
+	if cfg.Wild.Battle == nil {
+		cfg.Wild = nil
+		cfg.notef("Got away safely!\n")
+		return nil
+	}
+
+	escaped, messages := cfg.Wild.Battle.Run()
+	if escaped {
+		cfg.Wild = nil
+		for _, message := range messages {
+			cfg.notef("%s\n", message)
+		}
+		return nil
+	}
+	return cfg.printBattle(messages)
+}
+
+// printBattle prints the messages of a turn, then either the state of both
+// Pokémon or how the battle ended. Winning earns the player's Pokémon
+// experience and effort values.
+func (cfg *Config) printBattle(messages []string) error {
+	for _, message := range messages {
+		cfg.notef("%s\n", message)
+	}
+
+	b := cfg.Wild.Battle
+	switch {
+	case b.Wild.Fainted():
+		cfg.notef("%s won the battle!\n", b.Player.Name)
+		wild := cfg.Wild
+		cfg.Wild = nil
+		cfg.Trainer.BattlesWon++
+		return cfg.rewardFighter(wild, true)
+	case b.Player.Fainted():
+		cfg.notef("You hurry away from the wild %s.\n", b.Wild.Name)
+		cfg.Wild = nil
+	default:
+		cfg.notef("%s HP %d/%d | wild %s HP %d/%d\n",
+			b.Player.Name, b.Player.HP, b.Player.Stats.HP, b.Wild.Name, b.Wild.HP, b.Wild.Stats.HP)
+	}
+	return nil
+}
+
+// commandLead moves a Pokémon to the first party slot. A Pokémon in a box
+// trades places with the current lead.
+func commandLead(cfg *Config, commands []string) error {
+	if len(commands) == 0 {
+		lead, ok := cfg.leadPokemon()
+		if !ok {
+			cfg.notef("You haven't caught any Pokémon yet!\n")
+			return nil
+		}
+		cfg.notef("%s leads your team.\n", lead.Name())
+		return nil
+	}
+
+	pokemon, err := cfg.findPokemon(commands[0])
+	if errors.Is(err, errPokemonNotCaught) {
+		return fmt.Errorf("you have not caught %s", commands[0])
+	}
+	if err != nil {
+		return err
+	}
+	list, i := cfg.locate(pokemon.ID)
+	switch {
+	case list == &cfg.Party:
+		cfg.Party = slices.Insert(slices.Delete(cfg.Party, i, i+1), 0, pokemon.ID)
+	case len(cfg.Party) == 0:
+		*list = slices.Delete(*list, i, i+1)
+		cfg.Party = []string{pokemon.ID}
+	default:
+		(*list)[i], cfg.Party[0] = cfg.Party[0], pokemon.ID
+	}
+	cfg.notef("%s now leads your team.\n", pokemon.Name())
+	return nil
+}
+
+func completeFight(cfg *Config, args []string) []string {
+	if len(args) != 0 || cfg.Wild == nil || cfg.Wild.Battle == nil {
+		return nil
+	}
+	var names []string
+	for _, move := range cfg.Wild.Battle.Player.Moves {
+		names = append(names, move.Name)
+	}
+	return names
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// seedPikachu caches a Pikachu that knows thunder-shock, along with the move
+// and its type, and adds it to the player's Pokémon. Its growth rate makes
+// it reach level 6 after one battle, when it evolves into raichu.
+func seedPikachu(t *testing.T, cfg *Config) {
+	t.Helper()
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/pikachu", []byte(`{
+		"id": 25,
+		"name": "pikachu",
+		"stats": [
+			{"base_stat": 35, "stat": {"name": "hp"}},
+			{"base_stat": 55, "stat": {"name": "attack"}},
+			{"base_stat": 40, "stat": {"name": "defense"}},
+			{"base_stat": 50, "stat": {"name": "special-attack"}},
+			{"base_stat": 50, "stat": {"name": "special-defense"}},
+			{"base_stat": 90, "stat": {"name": "speed"}}
+		],
+		"types": [{"slot": 1, "type": {"name": "electric"}}],
+		"moves": [
+			{"move": {"name": "thunder-shock"}, "version_group_details": [
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}}
+			]},
+			{"move": {"name": "growl"}, "version_group_details": [
+				{"level_learned_at": 5, "move_learn_method": {"name": "level-up"}}
+			]}
+		]
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/move/thunder-shock", []byte(`{
+		"name": "thunder-shock", "power": 40, "accuracy": 100,
+		"type": {"name": "electric"}, "damage_class": {"name": "special"}
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/move/growl", []byte(`{
+		"name": "growl", "power": null, "accuracy": 100,
+		"type": {"name": "normal"}, "damage_class": {"name": "status"}
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/type/electric", []byte(`{
+		"name": "electric", "damage_relations": {"double_damage_to": [{"name": "water"}]}
+	}`))
+
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon-species/pikachu", []byte(`{
+		"id": 25, "name": "pikachu", "capture_rate": 190,
+		"growth_rate": {"name": "test"},
+		"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"}
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/growth-rate/test", []byte(`{
+		"name": "test",
+		"levels": [
+			{"level": 1, "experience": 0},
+			{"level": 5, "experience": 100},
+			{"level": 6, "experience": 110},
+			{"level": 7, "experience": 1000}
+		]
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/evolution-chain/10/", []byte(`{
+		"id": 10,
+		"chain": {"species": {"name": "pikachu"}, "evolves_to": [{
+			"species": {"name": "raichu"},
+			"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 6}],
+			"evolves_to": []
+		}]}
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/raichu", []byte(`{"id": 26, "name": "raichu"}`))
+
+	pikachu, err := pokeapi.GetPokemonInfo(cfg.cache, "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	givePokemon(cfg, *pikachu)
+}
+
+func TestBattleWildPokemon(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.reseed(1)
+	seedPikachu(t, cfg)
+	seedArea(cfg)
+	cfg.CurrentArea = "route-1"
+
+	if err := commandEncounter(cfg, nil); err != nil {
+		t.Fatalf("encounter returned an error: %v", err)
+	}
+	if cfg.Wild == nil || cfg.Wild.Battle == nil {
+		t.Fatalf("Expected the encounter to start a battle, got %q", out.String())
+	}
+	moves := cfg.Wild.Battle.Player.Moves
+	if len(moves) != 1 || moves[0].Name != "thunder-shock" {
+		t.Errorf("Expected only the damaging move to be usable, got %+v", moves)
+	}
+	if !strings.Contains(out.String(), "Go! pikachu!") {
+		t.Errorf("Expected the lead to be sent out, got %q", out.String())
+	}
+
+	if err := commandFight(cfg, []string{"thunderbolt"}); err == nil {
+		t.Error("Expected an error for a move pikachu doesn't know")
+	}
+	for turn := 0; cfg.Wild != nil; turn++ {
+		if turn == 10 {
+			t.Fatal("Battle didn't end")
+		}
+		if err := commandFight(cfg, []string{"1"}); err != nil {
+			t.Fatalf("fight returned an error: %v", err)
+		}
+	}
+	if !strings.Contains(out.String(), "pikachu won the battle!") {
+		t.Errorf("Expected pikachu to win, got %q", out.String())
+	}
+
+	lead, _ := cfg.leadPokemon()
+	if !strings.Contains(out.String(), "pikachu grew to level 6!") || lead.Level != 6 || lead.Experience < 110 {
+		t.Errorf("Expected pikachu to reach level 6, got level %d with %d experience", lead.Level, lead.Experience)
+	}
+	if !strings.Contains(out.String(), "pikachu evolved into raichu!") || lead.Species != "raichu" || lead.Info.ID != 26 {
+		t.Errorf("Expected pikachu to evolve into raichu, got %s", lead.Species)
+	}
+}
+
+func TestCatchOddsImproveWhenWeakened(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.reseed(1)
+	seedPikachu(t, cfg)
+	seedArea(cfg)
+	cfg.CurrentArea = "route-1"
+	if err := commandEncounter(cfg, nil); err != nil {
+		t.Fatalf("encounter returned an error: %v", err)
+	}
+
+	info, err := pokeapi.GetPokemonInfo(cfg.cache, cfg.Wild.Pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	healthy := cfg.Wild.catchAttempt(info, 45, 1).modifiedRate()
+	cfg.Wild.Foe.HP = 1
+	weakened := cfg.Wild.catchAttempt(info, 45, 1).modifiedRate()
+	if weakened <= healthy {
+		t.Errorf("Expected a weakened Pokémon to be easier to catch: %.1f at full HP, %.1f at 1 HP", healthy, weakened)
+	}
+}
+
+func TestRunAndLead(t *testing.T) {
+	cfg := createTestConfig()
+	if err := commandLead(cfg, []string{"pikachu"}); err == nil {
+		t.Error("Expected an error when choosing a Pokémon that wasn't caught")
+	}
+
+	seedPikachu(t, cfg)
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	if lead, _ := cfg.leadPokemon(); lead.Species != "pikachu" {
+		t.Errorf("Expected the first Pokémon caught to lead, got %s", lead.Species)
+	}
+	if err := commandLead(cfg, []string{"bulbasaur"}); err != nil {
+		t.Fatalf("lead returned an error: %v", err)
+	}
+	if lead, _ := cfg.leadPokemon(); lead.Species != "bulbasaur" {
+		t.Errorf("Expected bulbasaur to lead, got %s", lead.Species)
+	}
+
+	// A boxed Pokémon can lead even when the party is empty.
+	bulbasaur, _ := cfg.leadPokemon()
+	cfg.Party, cfg.Boxes = nil, [][]string{{bulbasaur.ID}}
+	if err := commandLead(cfg, []string{bulbasaur.ID}); err != nil {
+		t.Fatalf("lead from a box returned an error: %v", err)
+	}
+	if len(cfg.Party) != 1 || cfg.Party[0] != bulbasaur.ID || len(cfg.Boxes[0]) != 0 {
+		t.Errorf("Expected bulbasaur to move from the box into the party, have %v and %v", cfg.Party, cfg.Boxes)
+	}
+
+	meetWild(cfg, "rattata")
+	if err := commandRun(cfg, nil); err != nil {
+		t.Fatalf("run returned an error: %v", err)
+	}
+	if cfg.Wild != nil {
+		t.Error("Expected to get away when not battling")
+	}
+}
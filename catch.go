@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// shakeChecks is how many checks a Pokémon must pass to be caught. The ball
+// wobbles once for each of the first three it passes.
+const shakeChecks = 4
+
+// statusCatchBonus multiplies the catch rate of a Pokémon with a status
+// condition.
+var statusCatchBonus = map[string]float64{
+	"sleep":     2.5,
+	"freeze":    2.5,
+	"paralysis": 1.5,
+	"poison":    1.5,
+	"burn":      1.5,
+}
+
+// catchAttempt describes a wild Pokémon at the moment a ball is thrown.
+type catchAttempt struct {
+	CaptureRate int // Species capture rate, 3 to 255
+	MaxHP       int
+	CurrentHP   int
+	BallBonus   float64 // From ballCatchBonus
+	Status      string  // Key of statusCatchBonus, or "" for none
+}
+
+// catchAttempt describes throwing a ball at the wild Pokémon. Weakening it
+// in battle makes it easier to catch; without a battle it is at full health.
+func (w *wildEncounter) catchAttempt(pokemon *pokeapi.PokemonInfo, captureRate int, ballBonus float64) catchAttempt {
+	attempt := catchAttempt{
+		CaptureRate: captureRate,
+		MaxHP:       pokemon.BaseStat("hp"),
+		CurrentHP:   pokemon.BaseStat("hp"),
+		BallBonus:   ballBonus,
+	}
+	if w.Foe != nil {
+		attempt.MaxHP, attempt.CurrentHP, attempt.Status = w.Foe.Stats.HP, w.Foe.HP, w.Foe.Status
+	}
+	return attempt
+}
+
+// modifiedRate is the mainline games' modified catch rate: the species
+// capture rate scaled by remaining HP, the ball and any status condition.
+// A rate of 255 or more always catches.
+func (a catchAttempt) modifiedRate() float64 {
+	maxHP := float64(max(1, a.MaxHP))
+	currentHP := float64(min(max(1, a.CurrentHP), max(1, a.MaxHP)))
+	rate := (3*maxHP - 2*currentHP) * float64(a.CaptureRate) * a.BallBonus / (3 * maxHP)
+	if bonus, ok := statusCatchBonus[a.Status]; ok {
+		rate *= bonus
+	}
+	return rate
+}
+
+// shakeThreshold is the value a random number below 65536 must be under to
+// pass one shake check.
+func (a catchAttempt) shakeThreshold() int {
+	rate := a.modifiedRate()
+	if rate >= 255 {
+		return 65536
+	}
+	if rate <= 0 {
+		return 0
+	}
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/rate)))
+}
+
+// throw runs the shake checks, drawing random numbers from intn, and reports
+// how many times the ball wobbled and whether the Pokémon was caught.
+func (a catchAttempt) throw(intn func(n int) int) (wobbles int, caught bool) {
+	threshold := a.shakeThreshold()
+	for check := 0; check < shakeChecks; check++ {
+		if intn(65536) >= threshold {
+			return min(check, shakeChecks-1), false
+		}
+	}
+	return shakeChecks - 1, true
+}
+
+// catchDifficulty describes a capture rate for the player.
+func catchDifficulty(captureRate int) string {
+	switch {
+	case captureRate <= 10:
+		return "looks almost impossible to catch"
+	case captureRate <= 60:
+		return "looks hard to catch"
+	case captureRate <= 150:
+		return "looks tricky to catch"
+	}
+	return "looks easy to catch"
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCatchAttemptModifiedRate(t *testing.T) {
+	cases := []struct {
+		attempt catchAttempt
+		want    float64
+	}{
+		// At full health only a third of the capture rate counts.
+		{catchAttempt{CaptureRate: 255, MaxHP: 40, CurrentHP: 40, BallBonus: 1}, 85},
+		{catchAttempt{CaptureRate: 3, MaxHP: 106, CurrentHP: 106, BallBonus: 2}, 2},
+		// At 1 HP the rate approaches the full capture rate.
+		{catchAttempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 1, BallBonus: 1}, 44.7},
+		{catchAttempt{CaptureRate: 45, MaxHP: 90, CurrentHP: 90, BallBonus: 1, Status: "sleep"}, 37.5},
+		{catchAttempt{CaptureRate: 45, MaxHP: 90, CurrentHP: 90, BallBonus: 1.5, Status: "burn"}, 33.75},
+	}
+	for _, c := range cases {
+		if got := c.attempt.modifiedRate(); got < c.want-0.01 || got > c.want+0.01 {
+			t.Errorf("modifiedRate(%+v) = %.2f, want %.2f", c.attempt, got, c.want)
+		}
+	}
+}
+
+func TestCatchAttemptThrow(t *testing.T) {
+	hard := catchAttempt{CaptureRate: 3, MaxHP: 106, CurrentHP: 106, BallBonus: 1}
+	threshold := hard.shakeThreshold()
+	if threshold <= 0 || threshold >= 65536/2 {
+		t.Fatalf("Expected a low shake threshold for a legendary, got %d", threshold)
+	}
+
+	// Pass two checks, then fail the third.
+	rolls := []int{0, threshold - 1, threshold}
+	intn := func(n int) int {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+	wobbles, caught := hard.throw(intn)
+	if caught || wobbles != 2 {
+		t.Errorf("Expected 2 wobbles and an escape, got %d wobbles, caught=%v", wobbles, caught)
+	}
+
+	always := func(n int) int { return 0 }
+	if wobbles, caught := hard.throw(always); !caught || wobbles != 3 {
+		t.Errorf("Expected 3 wobbles and a catch, got %d wobbles, caught=%v", wobbles, caught)
+	}
+
+	master := catchAttempt{CaptureRate: 3, MaxHP: 106, CurrentHP: 106, BallBonus: ballCatchBonus["master-ball"]}
+	never := func(n int) int { return n - 1 }
+	if _, caught := master.throw(never); !caught {
+		t.Error("A master-ball should catch regardless of the rolls")
+	}
+}
+
+func TestCatchReportsCaptureRate(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	seedPokemon(cfg, "mewtwo", 306)
+	seedSpecies(cfg, "mewtwo", 3)
+	meetWild(cfg, "mewtwo")
+
+	if err := commandCatch(cfg, []string{"mewtwo"}); err != nil {
+		t.Fatalf("catch returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "mewtwo looks almost impossible to catch (capture rate 3)") {
+		t.Errorf("Expected the capture rate in the output, got %q", out.String())
+	}
+}
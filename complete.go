@@ -0,0 +1,141 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/output"
+)
+
+// complete returns tab completion candidates for the last word of line:
+// command names for the first word, otherwise whatever the command's
+// completer offers given the arguments before the one being typed.
+func (cfg *Config) complete(line string) []string {
+	fields := strings.Fields(line)
+	typingNewWord := len(fields) == 0 || strings.HasSuffix(line, " ")
+
+	if len(fields) == 0 || (len(fields) == 1 && !typingNewWord) {
+		return append(slices.Collect(maps.Keys(commands_map)), "help")
+	}
+
+	cmd, exists := commands_map[fields[0]]
+	if !exists || cmd.complete == nil {
+		return nil
+	}
+
+	args := fields[1:]
+	if !typingNewWord {
+		if strings.HasPrefix(fields[len(fields)-1], "-") {
+			return nil
+		}
+		args = args[:len(args)-1]
+	}
+	return cmd.complete(cfg, args)
+}
+
+// completeNames completes the first argument with every resource name of a
+// PokeAPI list endpoint.
+func completeNames(endpoint string) func(*Config, []string) []string {
+	return func(cfg *Config, args []string) []string {
+		if len(args) != 0 {
+			return nil
+		}
+		return cfg.knownNames(endpoint)
+	}
+}
+
+// completeWords completes each argument position from a fixed list of words.
+func completeWords(positions ...[]string) func(*Config, []string) []string {
+	return func(cfg *Config, args []string) []string {
+		if len(args) >= len(positions) {
+			return nil
+		}
+		return positions[len(args)]
+	}
+}
+
+// completeSet offers setting names, then the values the chosen setting
+// accepts.
+func completeSet(cfg *Config, args []string) []string {
+	if len(args) == 0 {
+		return []string{"autocorrect", "output"}
+	}
+	if len(args) > 1 {
+		return nil
+	}
+	switch args[0] {
+	case "autocorrect":
+		return []string{"on", "off"}
+	case "output":
+		values := make([]string, 0, len(output.Formats))
+		for _, format := range output.Formats {
+			values = append(values, string(format))
+		}
+		return values
+	}
+	return nil
+}
+
+func completeCaught(cfg *Config, args []string) []string {
+	if len(args) != 0 {
+		return nil
+	}
+	return cfg.caughtNames()
+}
+
+func completeTrade(cfg *Config, args []string) []string {
+	switch {
+	case len(args) == 0:
+		return []string{"export", "import", "key", "trust"}
+	case len(args) == 1 && args[0] == "export":
+		return cfg.caughtNames()
+	}
+	return nil
+}
+
+// completeSwap offers caught Pokémon for both places being swapped.
+func completeSwap(cfg *Config, args []string) []string {
+	if len(args) > 1 {
+		return nil
+	}
+	return cfg.caughtNames()
+}
+
+func completeCatch(cfg *Config, args []string) []string {
+	switch len(args) {
+	case 0:
+		return cfg.wildPokemon()
+	case 1:
+		return cfg.ballsInBag()
+	}
+	return nil
+}
+
+func completeUse(cfg *Config, args []string) []string {
+	switch len(args) {
+	case 0:
+		return cfg.Bag.Names()
+	case 1:
+		return cfg.wildPokemon()
+	}
+	return nil
+}
+
+// wildPokemon returns the name of the wild Pokémon being faced, if any.
+func (cfg *Config) wildPokemon() []string {
+	if cfg.Wild == nil {
+		return nil
+	}
+	return []string{cfg.Wild.Pokemon}
+}
+
+func (cfg *Config) ballsInBag() []string {
+	var balls []string
+	for _, name := range cfg.Bag.Names() {
+		if _, isBall := ballCatchBonus[name]; isBall {
+			balls = append(balls, name)
+		}
+	}
+	return balls
+}
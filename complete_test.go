@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func TestCompleteCommandNames(t *testing.T) {
+	cfg := createTestConfig()
+
+	candidates := cfg.complete("ma")
+	for _, expected := range []string{"map", "mapb", "help"} {
+		if !slices.Contains(candidates, expected) {
+			t.Errorf("Expected %q among command completions", expected)
+		}
+	}
+}
+
+func TestCompleteArguments(t *testing.T) {
+	cfg := createTestConfig()
+	givePokemon(cfg, pokeapi.PokemonInfo{Name: "pikachu"})
+
+	if got := cfg.complete("inspect pi"); !slices.Contains(got, "pikachu") {
+		t.Errorf("Expected caught Pokémon for inspect, got %v", got)
+	}
+	if got := cfg.complete("inspect pikachu "); got != nil {
+		t.Errorf("Expected no completions past inspect's only argument, got %v", got)
+	}
+	if got := cfg.complete("use "); !slices.Contains(got, "master-ball") {
+		t.Errorf("Expected bag items for use, got %v", got)
+	}
+	if got := cfg.complete("set autocorrect "); !slices.Equal(got, []string{"on", "off"}) {
+		t.Errorf("Expected on/off for set autocorrect, got %v", got)
+	}
+	if got := cfg.complete("set output "); !slices.Contains(got, "json") || slices.Contains(got, "on") {
+		t.Errorf("Expected output formats for set output, got %v", got)
+	}
+	if got := cfg.complete("explore --ver"); got != nil {
+		t.Errorf("Expected no completions for flags, got %v", got)
+	}
+	if got := cfg.complete("unknown "); got != nil {
+		t.Errorf("Expected no completions for unknown commands, got %v", got)
+	}
+}
+
+func TestCompleteCatchBalls(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Bag.Add("potion", 1)
+
+	got := cfg.complete("catch pikachu ")
+	if !slices.Contains(got, "great-ball") || slices.Contains(got, "potion") {
+		t.Errorf("Expected only balls as the second catch argument, got %v", got)
+	}
+}
+
+func TestCompleteNamesFromIndex(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.nameIndex[pokeapi.EndpointLocationAreas] = []string{"canalave-city-area", "eterna-city-area"}
+
+	if got := cfg.complete("explore ca"); !slices.Contains(got, "canalave-city-area") {
+		t.Errorf("Expected area names for explore, got %v", got)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// wildEncounter is the wild Pokémon the player is currently facing.
+type wildEncounter struct {
+	Pokemon string
+	Level   int
+	Area    string
+	Foe     *battle.Combatant // The wild Pokémon's battle state
+	Battle  *battle.Battle    // Nil when the player has no Pokémon to fight with
+	Fighter string            // ID of the player's Pokémon in the battle
+}
+
+// lookupArea fetches a location area, correcting misspelled names when
+// autocorrect is on. It returns the area along with the name that was found.
+func (cfg *Config) lookupArea(name string) (*pokeapi.LocationAreaDetail, string, error) {
+	area, err := pokeapi.GetLocationArea(cfg.cache, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		corrected, hint := cfg.correctName(name, cfg.knownNames(pokeapi.EndpointLocationAreas))
+		if corrected == "" {
+			return nil, "", fmt.Errorf("no location area named '%s'%s", name, hint)
+		}
+		cfg.notef("Assuming you meant %s.\n", corrected)
+		name = corrected
+		area, err = pokeapi.GetLocationArea(cfg.cache, name)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch location area '%s': %v", name, err)
+	}
+	return area, name, nil
+}
+
+// enterArea moves the player to a location area. Any wild Pokémon met in
+// the previous area is left behind.
+func (cfg *Config) enterArea(name string) {
+	if cfg.CurrentArea != name {
+		cfg.Wild = nil
+	}
+	cfg.CurrentArea = name
+	cfg.exploreArea(name)
+}
+
+func commandGoto(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		if cfg.CurrentArea == "" {
+			cfg.notef("You haven't gone anywhere yet. Use goto or explore with a location area name.\n")
+		} else {
+			cfg.notef("You are in %s.\n", cfg.CurrentArea)
+		}
+		return nil
+	}
+
+	_, name, err := cfg.lookupArea(commands[0])
+	if err != nil {
+		return err
+	}
+	cfg.enterArea(name)
+	cfg.notef("You are now in %s. Use encounter to look for wild Pokémon.\n", name)
+	return nil
+}
+
+func commandEncounter(cfg *Config, commands []string) error {
+	_, flags, err := parseArgs(commands)
+	if err != nil {
+		return err
+	}
+	if cfg.CurrentArea == "" {
+		return fmt.Errorf("you need to be somewhere to look for Pokémon; use goto or explore first")
+	}
+
+	area, err := pokeapi.GetLocationArea(cfg.cache, cfg.CurrentArea)
+	if err != nil {
+		return fmt.Errorf("failed to fetch location area '%s': %v", cfg.CurrentArea, err)
+	}
+	pool := encounterPool(area.Encounters(flags["version"]), flags["method"])
+	encounter, ok := drawEncounter(pool, cfg.rng)
+	if !ok && flags["method"] != "" {
+		cfg.notef("There are no wild Pokémon in %s to meet by %s.\n", cfg.CurrentArea, flags["method"])
+		return nil
+	}
+	if !ok {
+		cfg.notef("There are no wild Pokémon in %s.\n", cfg.CurrentArea)
+		return nil
+	}
+
+	level := encounter.MinLevel + cfg.rng.IntN(max(1, encounter.MaxLevel-encounter.MinLevel+1))
+	pokemon, err := pokeapi.GetPokemonInfo(cfg.cache, encounter.Pokemon)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", encounter.Pokemon, err)
+	}
+	foe, err := cfg.newCombatant(*pokemon, level, battle.CalcStats(baseStats(*pokemon), battle.Stats{}, battle.Stats{}, level))
+	if err != nil {
+		return err
+	}
+
+	cfg.Wild = &wildEncounter{
+		Pokemon: encounter.Pokemon,
+		Level:   level,
+		Area:    cfg.CurrentArea,
+		Foe:     foe,
+	}
+	cfg.markSeen(pokemon.SpeciesName(), pokemon.SpeciesID())
+	cfg.notef("A wild %s (Lv. %d) appeared!\n", cfg.Wild.Pokemon, cfg.Wild.Level)
+	return cfg.startBattle()
+}
+
+// encounterPool narrows an area's encounters to a single game version and
+// encounter method, since chances only add up within one of each. The
+// version is the first one listed and the method defaults to walking, or to
+// the first method listed where there is no walking.
+func encounterPool(encounters []pokeapi.EncounterSummary, method string) []pokeapi.EncounterSummary {
+	if len(encounters) == 0 {
+		return nil
+	}
+	version := encounters[0].Version
+	if method == "" {
+		method = encounters[0].Method
+		for _, encounter := range encounters {
+			if encounter.Version == version && encounter.Method == "walk" {
+				method = "walk"
+				break
+			}
+		}
+	}
+
+	var pool []pokeapi.EncounterSummary
+	for _, encounter := range encounters {
+		if encounter.Version == version && encounter.Method == method {
+			pool = append(pool, encounter)
+		}
+	}
+	return pool
+}
+
+// drawEncounter picks one encounter at random, weighted by its chance.
+func drawEncounter(encounters []pokeapi.EncounterSummary, rng *rand.Rand) (pokeapi.EncounterSummary, bool) {
+	total := 0
+	for _, encounter := range encounters {
+		total += max(0, encounter.Chance)
+	}
+	if total == 0 {
+		return pokeapi.EncounterSummary{}, false
+	}
+
+	roll := rng.IntN(total)
+	for _, encounter := range encounters {
+		roll -= max(0, encounter.Chance)
+		if roll < 0 {
+			return encounter, true
+		}
+	}
+	return pokeapi.EncounterSummary{}, false
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func seedArea(cfg *Config) {
+	cfg.cache.Add("https://pokeapi.co/api/v2/location-area/route-1", []byte(`{
+		"name": "route-1",
+		"pokemon_encounters": [
+			{"pokemon": {"name": "pidgey", "url": ""}, "version_details": [
+				{"version": {"name": "red", "url": ""}, "max_chance": 30, "encounter_details": [
+					{"min_level": 2, "max_level": 5, "chance": 30, "condition_values": [], "method": {"name": "walk", "url": ""}}
+				]}
+			]},
+			{"pokemon": {"name": "rattata", "url": ""}, "version_details": [
+				{"version": {"name": "red", "url": ""}, "max_chance": 70, "encounter_details": [
+					{"min_level": 3, "max_level": 4, "chance": 70, "condition_values": [], "method": {"name": "walk", "url": ""}}
+				]}
+			]}
+		]
+	}`))
+	seedPokemon(cfg, "pidgey", 50)
+	seedPokemon(cfg, "rattata", 51)
+}
+
+func TestEncounterInCurrentArea(t *testing.T) {
+	cfg := createTestConfig()
+	seedArea(cfg)
+
+	if err := commandEncounter(cfg, nil); err == nil {
+		t.Error("Expected an error when encountering before going anywhere")
+	}
+	if err := commandGoto(cfg, []string{"route-1"}); err != nil {
+		t.Fatalf("goto returned an error: %v", err)
+	}
+	if cfg.CurrentArea != "route-1" {
+		t.Errorf("Expected to be in route-1, got %q", cfg.CurrentArea)
+	}
+
+	for range 20 {
+		if err := commandEncounter(cfg, nil); err != nil {
+			t.Fatalf("encounter returned an error: %v", err)
+		}
+		wild := cfg.Wild
+		switch {
+		case wild == nil:
+			t.Fatal("Expected a wild Pokémon to appear")
+		case wild.Pokemon == "pidgey" && (wild.Level < 2 || wild.Level > 5),
+			wild.Pokemon == "rattata" && (wild.Level < 3 || wild.Level > 4):
+			t.Errorf("Level out of range: %+v", wild)
+		case wild.Pokemon != "pidgey" && wild.Pokemon != "rattata":
+			t.Errorf("Unexpected wild Pokémon: %+v", wild)
+		}
+	}
+}
+
+func TestEncounterPicksOneVersionAndMethod(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/location-area/route-2", []byte(`{
+		"name": "route-2",
+		"pokemon_encounters": [
+			{"pokemon": {"name": "magikarp", "url": ""}, "version_details": [
+				{"version": {"name": "red", "url": ""}, "max_chance": 100, "encounter_details": [
+					{"min_level": 5, "max_level": 5, "chance": 100, "condition_values": [], "method": {"name": "old-rod", "url": ""}}
+				]}
+			]},
+			{"pokemon": {"name": "pidgey", "url": ""}, "version_details": [
+				{"version": {"name": "red", "url": ""}, "max_chance": 100, "encounter_details": [
+					{"min_level": 3, "max_level": 5, "chance": 100, "condition_values": [], "method": {"name": "walk", "url": ""}}
+				]}
+			]},
+			{"pokemon": {"name": "spearow", "url": ""}, "version_details": [
+				{"version": {"name": "blue", "url": ""}, "max_chance": 100, "encounter_details": [
+					{"min_level": 3, "max_level": 5, "chance": 100, "condition_values": [], "method": {"name": "walk", "url": ""}}
+				]}
+			]}
+		]
+	}`))
+	seedPokemon(cfg, "magikarp", 40)
+	seedPokemon(cfg, "pidgey", 50)
+	seedPokemon(cfg, "spearow", 52)
+	if err := commandGoto(cfg, []string{"route-2"}); err != nil {
+		t.Fatalf("goto returned an error: %v", err)
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "pidgey"},
+		{[]string{"--method", "old-rod"}, "magikarp"},
+		{[]string{"--version", "blue"}, "spearow"},
+	}
+	for _, tt := range tests {
+		for range 10 {
+			if err := commandEncounter(cfg, tt.args); err != nil {
+				t.Fatalf("encounter %v returned an error: %v", tt.args, err)
+			}
+			if cfg.Wild == nil || cfg.Wild.Pokemon != tt.want {
+				t.Fatalf("Expected encounter %v to meet %s, got %+v", tt.args, tt.want, cfg.Wild)
+			}
+		}
+	}
+
+	cfg.Wild = nil
+	if err := commandEncounter(cfg, []string{"--method", "surf"}); err != nil {
+		t.Fatalf("encounter returned an error: %v", err)
+	}
+	if cfg.Wild != nil {
+		t.Errorf("Expected nothing to meet by surfing, got %+v", cfg.Wild)
+	}
+}
+
+func TestDrawEncounterWeighted(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	encounters := []pokeapi.EncounterSummary{
+		{Pokemon: "common", Chance: 90},
+		{Pokemon: "rare", Chance: 10},
+		{Pokemon: "never", Chance: 0},
+	}
+
+	counts := make(map[string]int)
+	for range 1000 {
+		encounter, ok := drawEncounter(encounters, rng)
+		if !ok {
+			t.Fatal("Expected an encounter")
+		}
+		counts[encounter.Pokemon]++
+	}
+	if counts["never"] != 0 || counts["rare"] < 50 || counts["rare"] > 150 {
+		t.Errorf("Unexpected distribution: %v", counts)
+	}
+
+	if _, ok := drawEncounter(nil, rng); ok {
+		t.Error("Expected no encounter from an empty area")
+	}
+}
+
+func TestCatchRestrictedToWildPokemon(t *testing.T) {
+	cfg := createTestConfig()
+	seedPokemon(cfg, "mewtwo", 306)
+	before := cfg.Bag["master-ball"]
+
+	err := commandCatch(cfg, []string{"mewtwo", "master-ball"})
+	if err == nil || !strings.Contains(err.Error(), "no wild Pokémon") {
+		t.Errorf("Expected to be told there is nothing to catch, got %v", err)
+	}
+
+	meetWild(cfg, "rattata")
+	err = commandCatch(cfg, []string{"mewtwo", "master-ball"})
+	if err == nil || !strings.Contains(err.Error(), "only a wild rattata") {
+		t.Errorf("Expected to be told which Pokémon is here, got %v", err)
+	}
+	if caught := hasCaught(cfg, "mewtwo"); caught || cfg.Bag["master-ball"] != before {
+		t.Error("A Pokémon that wasn't encountered must not be caught or use up a ball")
+	}
+
+	seedArea(cfg)
+	cfg.CurrentArea = "somewhere-else"
+	if err := commandGoto(cfg, []string{"route-1"}); err != nil {
+		t.Fatalf("goto returned an error: %v", err)
+	}
+	if cfg.Wild != nil {
+		t.Error("Expected the wild Pokémon to be left behind when moving")
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/output"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// emit writes records in the configured structured output format. It
+// reports false when the text format is selected, in which case the caller
+// prints its usual human-readable output instead.
+func (cfg *Config) emit(records any) (bool, error) {
+	if cfg.Output == "" || cfg.Output == output.Text {
+		return false, nil
+	}
+	return true, output.Write(cfg.out, cfg.Output, records)
+}
+
+// notef prints an informational message. In structured output modes it goes
+// to cfg.errOut so it doesn't corrupt the records written to cfg.out.
+func (cfg *Config) notef(format string, args ...any) {
+	w := cfg.out
+	if cfg.Output != "" && cfg.Output != output.Text {
+		w = cfg.errOut
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+type bagRecord struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
+
+// moveRecord is one of the moves the player's Pokémon can use in battle.
+type moveRecord struct {
+	Number   int    `json:"number"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Power    int    `json:"power"`
+	Accuracy int    `json:"accuracy"` // 0 for moves that never miss
+}
+
+// tradeKeyRecord is a trainer's public trade key.
+type tradeKeyRecord struct {
+	Trainer string `json:"trainer"`
+	Key     string `json:"key"`
+}
+
+type pokedexRecord struct {
+	ID     int    `json:"id"` // National Pokédex number
+	Name   string `json:"name"`
+	Status string `json:"status"` // caught, seen or missing
+}
+
+// caughtRecord is one row of the party and box listings.
+type caughtRecord struct {
+	Box     int      `json:"box,omitempty"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Species string   `json:"species"`
+	Level   int      `json:"level"`
+	Types   []string `json:"types"`
+}
+
+func newCaughtRecord(pokemon *CaughtPokemon) caughtRecord {
+	return caughtRecord{
+		ID:      pokemon.ID,
+		Name:    pokemon.Name(),
+		Species: pokemon.Species,
+		Level:   pokemon.Level,
+		Types:   append([]string{}, typeNames(pokemon.Info)...),
+	}
+}
+
+// pokemonRecord flattens a caught Pokémon's PokemonInfo so stats and types
+// become simple columns in tabular formats.
+type pokemonRecord struct {
+	ID              int            `json:"id"`
+	Name            string         `json:"name"`
+	CaughtID        string         `json:"caught_id"`
+	Nickname        string         `json:"nickname,omitempty"`
+	Level           int            `json:"level"`
+	Experience      int            `json:"experience"`
+	Nature          string         `json:"nature,omitempty"`
+	Gender          string         `json:"gender,omitempty"`
+	Shiny           bool           `json:"shiny"`
+	CaughtAt        time.Time      `json:"caught_at"`
+	Location        string         `json:"location,omitempty"`
+	OriginalTrainer string         `json:"original_trainer,omitempty"`
+	Height          int            `json:"height"`
+	Weight          int            `json:"weight"`
+	BaseExperience  int            `json:"base_experience"`
+	Types           []string       `json:"types"`
+	Stats           map[string]int `json:"stats"`       // Base stats
+	LevelStats      map[string]int `json:"level_stats"` // Stats at the Pokémon's level
+	IVs             battle.Stats   `json:"ivs"`
+	EVs             battle.Stats   `json:"evs"`
+}
+
+// typeNames lists a Pokémon's types in slot order.
+func typeNames(pokemon pokeapi.PokemonInfo) []string {
+	names := make([]string, 0, len(pokemon.Types))
+	for _, pokemonType := range pokemon.Types {
+		names = append(names, pokemonType.Type.Name)
+	}
+	return names
+}
+
+func newPokemonRecord(caught *CaughtPokemon) pokemonRecord {
+	pokemon := caught.Info
+	levelStats := caught.Stats()
+	record := pokemonRecord{
+		ID:              pokemon.ID,
+		Name:            pokemon.Name,
+		CaughtID:        caught.ID,
+		Nickname:        caught.Nickname,
+		Level:           caught.Level,
+		Experience:      caught.Experience,
+		Nature:          caught.Nature,
+		Gender:          caught.Gender,
+		Shiny:           caught.Shiny,
+		IVs:             caught.IVs,
+		EVs:             caught.EVs,
+		CaughtAt:        caught.CaughtAt,
+		Location:        caught.Location,
+		OriginalTrainer: caught.OriginalTrainer,
+		Height:          pokemon.Height,
+		Weight:          pokemon.Weight,
+		BaseExperience:  pokemon.BaseExperience,
+		Types:           []string{},
+		Stats:           make(map[string]int),
+		LevelStats:      make(map[string]int),
+	}
+	record.Types = append(record.Types, typeNames(pokemon)...)
+	for _, stat := range pokemon.Stats {
+		record.Stats[stat.Stat.Name] = stat.BaseStat
+		record.LevelStats[stat.Stat.Name] = levelStats.Get(stat.Stat.Name)
+	}
+	return record
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/output"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func TestInspectJSONOutput(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.Output = output.JSON
+	pikachu := pokeapi.PokemonInfo{ID: 25, Name: "pikachu", Height: 4, Weight: 60}
+	pikachu.Stats = append(pikachu.Stats, pokeapi.PokemonStat{BaseStat: 35})
+	pikachu.Stats[0].Stat.Name = "hp"
+	pikachu.Types = append(pikachu.Types, pokeapi.PokemonType{})
+	pikachu.Types[0].Type.Name = "electric"
+	givePokemon(cfg, pikachu)
+
+	if err := commandInspect(cfg, []string{"pikachu"}); err != nil {
+		t.Fatalf("inspect returned an error: %v", err)
+	}
+
+	var record pokemonRecord
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", out.String(), err)
+	}
+	if record.ID != 25 || record.Stats["hp"] != 35 || len(record.Types) != 1 || record.Types[0] != "electric" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+}
+
+func TestBagCSVOutput(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.Output = output.CSV
+	cfg.Bag = Inventory{"poke-ball": 2, "ultra-ball": 1}
+
+	if err := commandBag(cfg, nil); err != nil {
+		t.Fatalf("bag returned an error: %v", err)
+	}
+	want := "item,quantity\npoke-ball,2\nultra-ball,1\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+}
+
+func TestPokedexYAMLOutputEmpty(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.Output = output.YAML
+
+	if err := commandPokedex(cfg, nil); err != nil {
+		t.Fatalf("pokedex returned an error: %v", err)
+	}
+	if out.String() != "[]\n" {
+		t.Errorf("Expected an empty YAML list, got %q", out.String())
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandSet(cfg, []string{"output", "yaml"}); err != nil {
+		t.Fatalf("set output yaml returned an error: %v", err)
+	}
+	if cfg.Output != output.YAML {
+		t.Errorf("Expected yaml output, got %s", cfg.Output)
+	}
+	err := commandSet(cfg, []string{"output", "xml"})
+	if err == nil || !strings.Contains(err.Error(), "unknown output format") {
+		t.Errorf("Expected an unknown format error, got %v", err)
+	}
+	if cfg.Output != output.YAML {
+		t.Errorf("Expected output to stay yaml after a bad value, got %s", cfg.Output)
+	}
+}
+
+func TestActionsKeepStructuredOutputClean(t *testing.T) {
+	cfg := createTestConfig()
+	var out, errOut bytes.Buffer
+	cfg.out, cfg.errOut = &out, &errOut
+	cfg.Output = output.JSON
+	cfg.reseed(1)
+	seedPikachu(t, cfg)
+	seedArea(cfg)
+	cfg.CurrentArea = "route-1"
+
+	if err := commandEncounter(cfg, nil); err != nil {
+		t.Fatalf("encounter returned an error: %v", err)
+	}
+	if err := commandFight(cfg, []string{"1"}); err != nil {
+		t.Fatalf("fight returned an error: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected battle messages to stay out of the JSON output, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "appeared!") || !strings.Contains(errOut.String(), "thunder-shock") {
+		t.Errorf("Expected battle messages in the error writer, got %q", errOut.String())
+	}
+
+	if err := commandFight(cfg, nil); err != nil {
+		t.Fatalf("fight returned an error: %v", err)
+	}
+	var moves []moveRecord
+	if err := json.Unmarshal(out.Bytes(), &moves); err != nil || len(moves) != 1 || moves[0].Name != "thunder-shock" {
+		t.Errorf("Expected the moves as JSON, got %q: %v", out.String(), err)
+	}
+}
@@ -0,0 +1,175 @@
+// Package battle runs turn-based battles between two Pokémon using the
+// mainline games' damage formula, type effectiveness and STAB.
+package battle
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"unicode/utf8"
+)
+
+// Move is a move as used in battle. Accuracy is 0 for moves that never miss.
+type Move struct {
+	Name        string
+	Type        string
+	DamageClass string // physical, special or status
+	Power       int
+	Accuracy    int
+	Priority    int
+}
+
+// Struggle is used by Pokémon that have no damaging moves.
+var Struggle = Move{Name: "struggle", DamageClass: "physical", Power: 50}
+
+// Combatant is a Pokémon taking part in a battle.
+type Combatant struct {
+	Name   string
+	Level  int
+	Types  []string
+	Stats  Stats // Stats at Level; Stats.HP is the maximum HP
+	HP     int
+	Moves  []Move
+	Status string // Status condition such as "sleep", or "" for none
+	Wild   bool
+}
+
+// NewCombatant returns a combatant at full health. Pokémon without moves
+// fall back to Struggle.
+func NewCombatant(name string, level int, types []string, stats Stats, moves []Move) *Combatant {
+	if len(moves) == 0 {
+		moves = []Move{Struggle}
+	}
+	return &Combatant{Name: name, Level: level, Types: types, Stats: stats, HP: stats.HP, Moves: moves}
+}
+
+// DisplayName is the name used in battle messages.
+func (c *Combatant) DisplayName() string {
+	if c.Wild {
+		return "the wild " + c.Name
+	}
+	return c.Name
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+// FindMove returns the combatant's move with the given name or 1-based number.
+func (c *Combatant) FindMove(nameOrNumber string) (Move, bool) {
+	for i, move := range c.Moves {
+		if move.Name == nameOrNumber || fmt.Sprint(i+1) == nameOrNumber {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
+
+func (c *Combatant) hasType(pokemonType string) bool {
+	for _, t := range c.Types {
+		if t == pokemonType {
+			return true
+		}
+	}
+	return false
+}
+
+// Battle is a battle between the player's Pokémon and a wild one.
+type Battle struct {
+	Player      *Combatant
+	Wild        *Combatant
+	Chart       TypeChart
+	rng         *rand.Rand
+	escapeTries int
+}
+
+func New(player, wild *Combatant, chart TypeChart, rng *rand.Rand) *Battle {
+	wild.Wild = true
+	return &Battle{Player: player, Wild: wild, Chart: chart, rng: rng}
+}
+
+// Over reports whether either side has fainted.
+func (b *Battle) Over() bool {
+	return b.Player.Fainted() || b.Wild.Fainted()
+}
+
+// Turn plays one turn: the player uses move and the wild Pokémon answers
+// with a random move of its own. The faster Pokémon, or the one using the
+// higher priority move, goes first. It returns what happened, in order.
+func (b *Battle) Turn(move Move) []string {
+	wildMove := b.Wild.Moves[b.rng.IntN(len(b.Wild.Moves))]
+
+	playerFirst := move.Priority > wildMove.Priority
+	if move.Priority == wildMove.Priority {
+		switch {
+		case b.Player.Stats.Speed != b.Wild.Stats.Speed:
+			playerFirst = b.Player.Stats.Speed > b.Wild.Stats.Speed
+		default:
+			playerFirst = b.rng.IntN(2) == 0
+		}
+	}
+
+	if playerFirst {
+		return append(b.attack(b.Player, b.Wild, move), b.attack(b.Wild, b.Player, wildMove)...)
+	}
+	return append(b.attack(b.Wild, b.Player, wildMove), b.attack(b.Player, b.Wild, move)...)
+}
+
+// WildAttack lets the wild Pokémon attack without the player acting, as
+// happens after a failed escape.
+func (b *Battle) WildAttack() []string {
+	return b.attack(b.Wild, b.Player, b.Wild.Moves[b.rng.IntN(len(b.Wild.Moves))])
+}
+
+// Run tries to flee. The odds improve with the player's speed relative to
+// the wild Pokémon and with every failed attempt; a failed attempt gives the
+// wild Pokémon a free attack.
+func (b *Battle) Run() (bool, []string) {
+	b.escapeTries++
+	odds := b.Player.Stats.Speed*128/max(1, b.Wild.Stats.Speed) + 30*(b.escapeTries-1)
+	if odds > 255 || b.rng.IntN(256) < odds%256 {
+		return true, []string{"Got away safely!"}
+	}
+	return false, append([]string{"Can't escape!"}, b.WildAttack()...)
+}
+
+func (b *Battle) attack(attacker, defender *Combatant, move Move) []string {
+	if b.Over() {
+		return nil
+	}
+
+	messages := []string{fmt.Sprintf("%s used %s!", capitalize(attacker.DisplayName()), move.Name)}
+	if move.Accuracy > 0 && b.rng.IntN(100) >= move.Accuracy {
+		return append(messages, fmt.Sprintf("%s's attack missed!", capitalize(attacker.DisplayName())))
+	}
+	if move.Power <= 0 {
+		return append(messages, "But nothing happened.")
+	}
+
+	hit := Damage(attacker, defender, move, b.Chart, b.rng)
+	switch {
+	case hit.Effectiveness == 0:
+		return append(messages, fmt.Sprintf("It doesn't affect %s...", defender.DisplayName()))
+	case hit.Critical:
+		messages = append(messages, "A critical hit!")
+	}
+	switch {
+	case hit.Effectiveness > 1:
+		messages = append(messages, "It's super effective!")
+	case hit.Effectiveness < 1:
+		messages = append(messages, "It's not very effective...")
+	}
+
+	defender.HP = max(0, defender.HP-hit.Damage)
+	messages = append(messages, fmt.Sprintf("%s took %d damage.", capitalize(defender.DisplayName()), hit.Damage))
+	if defender.Fainted() {
+		messages = append(messages, fmt.Sprintf("%s fainted!", capitalize(defender.DisplayName())))
+	}
+	return messages
+}
+
+// capitalize upper-cases the first letter of s, which may be any rune.
+func capitalize(s string) string {
+	_, size := utf8.DecodeRuneInString(s)
+	return strings.ToUpper(s[:size]) + s[size:]
+}
@@ -0,0 +1,95 @@
+package battle
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestBattle(playerSpeed, wildSpeed int) *Battle {
+	tackle := Move{Name: "tackle", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100}
+	player := NewCombatant("pikachu", 10, []string{"electric"},
+		Stats{HP: 30, Attack: 20, Defense: 15, SpecialAttack: 20, SpecialDefense: 15, Speed: playerSpeed},
+		[]Move{tackle, {Name: "quick-attack", Type: "normal", DamageClass: "physical", Power: 40, Priority: 1}})
+	wild := NewCombatant("rattata", 3, []string{"normal"},
+		Stats{HP: 14, Attack: 9, Defense: 8, SpecialAttack: 7, SpecialDefense: 8, Speed: wildSpeed}, nil)
+	return New(player, wild, TypeChart{}, rand.New(rand.NewPCG(1, 2)))
+}
+
+func TestNewCombatantFallsBackToStruggle(t *testing.T) {
+	b := newTestBattle(20, 10)
+	if !slices.Equal(b.Wild.Moves, []Move{Struggle}) {
+		t.Errorf("Expected struggle for a Pokémon without moves, got %v", b.Wild.Moves)
+	}
+	if b.Wild.HP != b.Wild.Stats.HP || !b.Wild.Wild {
+		t.Errorf("Expected a wild combatant at full health, got %+v", b.Wild)
+	}
+}
+
+func TestTurnOrder(t *testing.T) {
+	b := newTestBattle(20, 10)
+	tackle, _ := b.Player.FindMove("tackle")
+	messages := b.Turn(tackle)
+	if len(messages) == 0 || messages[0] != "Pikachu used tackle!" {
+		t.Errorf("Expected the faster Pokémon to move first, got %q", messages)
+	}
+
+	b = newTestBattle(5, 10)
+	messages = b.Turn(tackle)
+	if messages[0] != "The wild rattata used struggle!" {
+		t.Errorf("Expected the faster wild Pokémon to move first, got %q", messages)
+	}
+
+	b = newTestBattle(5, 10)
+	quickAttack, ok := b.Player.FindMove("2")
+	if !ok || quickAttack.Name != "quick-attack" {
+		t.Fatalf("Expected move 2 to be quick-attack, got %+v", quickAttack)
+	}
+	if messages := b.Turn(quickAttack); messages[0] != "Pikachu used quick-attack!" {
+		t.Errorf("Expected a priority move to go first, got %q", messages)
+	}
+}
+
+func TestBattleEndsWhenWildFaints(t *testing.T) {
+	b := newTestBattle(20, 10)
+	tackle, _ := b.Player.FindMove("tackle")
+
+	var log []string
+	for turn := 0; !b.Over(); turn++ {
+		if turn == 20 {
+			t.Fatal("Battle didn't end")
+		}
+		log = append(log, b.Turn(tackle)...)
+	}
+	if !b.Wild.Fainted() || b.Player.Fainted() {
+		t.Fatalf("Expected the wild Pokémon to faint first, got player %d HP, wild %d HP", b.Player.HP, b.Wild.HP)
+	}
+	if last := log[len(log)-1]; last != "The wild rattata fainted!" {
+		t.Errorf("Expected the battle to end with the faint, got %q", strings.Join(log, "\n"))
+	}
+	if messages := b.Turn(tackle); messages != nil {
+		t.Errorf("Expected no more moves after the battle is over, got %q", messages)
+	}
+}
+
+func TestRunFromSlowerPokemon(t *testing.T) {
+	b := newTestBattle(40, 10)
+	if escaped, messages := b.Run(); !escaped || messages[0] != "Got away safely!" {
+		t.Errorf("Expected to outrun a much slower Pokémon, got %v %q", escaped, messages)
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	tests := map[string]string{
+		"":        "",
+		"pikachu": "Pikachu",
+		"élan":    "Élan",
+		"ñu":      "Ñu",
+	}
+	for in, want := range tests {
+		if got := capitalize(in); got != want {
+			t.Errorf("capitalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package battle
+
+import (
+	"math/rand/v2"
+)
+
+// Stats are a Pokémon's six stats, either base values or values at a level.
+type Stats struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special-attack"`
+	SpecialDefense int `json:"special-defense"`
+	Speed          int `json:"speed"`
+}
+
+// StatNames are the PokeAPI names of the stats, in the order of Stats.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// Get returns the stat with the given PokeAPI name, or 0 for unknown names.
+func (s Stats) Get(name string) int {
+	switch name {
+	case "hp":
+		return s.HP
+	case "attack":
+		return s.Attack
+	case "defense":
+		return s.Defense
+	case "special-attack":
+		return s.SpecialAttack
+	case "special-defense":
+		return s.SpecialDefense
+	case "speed":
+		return s.Speed
+	}
+	return 0
+}
+
+// Set changes the stat with the given PokeAPI name; unknown names are ignored.
+func (s *Stats) Set(name string, value int) {
+	switch name {
+	case "hp":
+		s.HP = value
+	case "attack":
+		s.Attack = value
+	case "defense":
+		s.Defense = value
+	case "special-attack":
+		s.SpecialAttack = value
+	case "special-defense":
+		s.SpecialDefense = value
+	case "speed":
+		s.Speed = value
+	}
+}
+
+// CalcStats computes the stats at level from base stats, individual values
+// (0-31) and effort values (0-252), using the mainline games' formulas.
+func CalcStats(base, ivs, evs Stats, level int) Stats {
+	var stats Stats
+	for _, name := range StatNames {
+		value := (2*base.Get(name) + ivs.Get(name) + evs.Get(name)/4) * level / 100
+		if name == "hp" {
+			value += level + 10
+		} else {
+			value += 5
+		}
+		stats.Set(name, value)
+	}
+	return stats
+}
+
+// ApplyNature raises the increased stat by 10% and lowers the decreased
+// stat by 10%, as a nature does. HP is never affected.
+func ApplyNature(stats Stats, increased, decreased string) Stats {
+	if increased == decreased {
+		return stats
+	}
+	if increased != "" && increased != "hp" {
+		stats.Set(increased, stats.Get(increased)*110/100)
+	}
+	if decreased != "" && decreased != "hp" {
+		stats.Set(decreased, stats.Get(decreased)*90/100)
+	}
+	return stats
+}
+
+// TypeChart holds damage multipliers by attacking type, then defending type.
+// Matchups that are missing are neutral.
+type TypeChart map[string]map[string]float64
+
+// Effectiveness returns the multiplier of a move of attackType against a
+// Pokémon with the given types.
+func (c TypeChart) Effectiveness(attackType string, defenderTypes []string) float64 {
+	multiplier := 1.0
+	for _, defenderType := range defenderTypes {
+		if m, ok := c[attackType][defenderType]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// criticalHitChance is the one-in-n chance of a critical hit.
+const criticalHitChance = 24
+
+// Hit is the outcome of one damaging move.
+type Hit struct {
+	Damage        int
+	Effectiveness float64
+	Critical      bool
+}
+
+// Damage computes the damage move deals from attacker to defender with the
+// mainline games' formula: level, power and the attack to defense ratio,
+// then a random factor, same-type attack bonus (STAB), type effectiveness
+// and critical hits.
+func Damage(attacker, defender *Combatant, move Move, chart TypeChart, rng *rand.Rand) Hit {
+	if move.Power <= 0 {
+		return Hit{Effectiveness: 1}
+	}
+
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.DamageClass == "special" {
+		attack, defense = attacker.Stats.SpecialAttack, defender.Stats.SpecialDefense
+	}
+	base := float64((2*attacker.Level/5+2)*move.Power*max(1, attack)/max(1, defense))/50 + 2
+
+	hit := Hit{Effectiveness: chart.Effectiveness(move.Type, defender.Types)}
+	modifier := float64(85+rng.IntN(16)) / 100 * hit.Effectiveness
+	if move.Type != "" && attacker.hasType(move.Type) {
+		modifier *= 1.5
+	}
+	if rng.IntN(criticalHitChance) == 0 {
+		hit.Critical = true
+		modifier *= 1.5
+	}
+
+	if hit.Effectiveness > 0 {
+		hit.Damage = max(1, int(base*modifier))
+	}
+	return hit
+}
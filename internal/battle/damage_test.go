@@ -0,0 +1,86 @@
+package battle
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCalcStats(t *testing.T) {
+	base := Stats{HP: 35, Attack: 55, Defense: 40, SpecialAttack: 50, SpecialDefense: 50, Speed: 90}
+
+	got := CalcStats(base, Stats{}, Stats{}, 50)
+	want := Stats{HP: 95, Attack: 60, Defense: 45, SpecialAttack: 55, SpecialDefense: 55, Speed: 95}
+	if got != want {
+		t.Errorf("CalcStats at level 50 = %+v, want %+v", got, want)
+	}
+
+	perfect := Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}
+	trained := Stats{Speed: 252}
+	if got := CalcStats(base, perfect, trained, 100); got.HP != 211 || got.Speed != 279 {
+		t.Errorf("CalcStats at level 100 = %+v, want HP 211 and speed 279", got)
+	}
+}
+
+func TestApplyNature(t *testing.T) {
+	stats := Stats{HP: 95, Attack: 60, SpecialAttack: 55}
+
+	got := ApplyNature(stats, "attack", "special-attack")
+	if got.Attack != 66 || got.SpecialAttack != 49 || got.HP != 95 {
+		t.Errorf("Unexpected stats with an adamant nature: %+v", got)
+	}
+	if got := ApplyNature(stats, "attack", "attack"); got != stats {
+		t.Errorf("A neutral nature changed the stats to %+v", got)
+	}
+}
+
+func TestTypeChartEffectiveness(t *testing.T) {
+	chart := TypeChart{
+		"electric": {"water": 2, "flying": 2, "ground": 0, "grass": 0.5},
+	}
+	cases := []struct {
+		defender []string
+		want     float64
+	}{
+		{[]string{"water", "flying"}, 4},
+		{[]string{"water", "grass"}, 1},
+		{[]string{"water", "ground"}, 0},
+		{[]string{"normal"}, 1},
+	}
+	for _, c := range cases {
+		if got := chart.Effectiveness("electric", c.defender); got != c.want {
+			t.Errorf("Effectiveness against %v = %v, want %v", c.defender, got, c.want)
+		}
+	}
+}
+
+func TestDamageModifiers(t *testing.T) {
+	stats := Stats{HP: 50, Attack: 50, Defense: 50, SpecialAttack: 50, SpecialDefense: 50, Speed: 50}
+	attacker := NewCombatant("pikachu", 20, []string{"electric"}, stats, nil)
+	water := NewCombatant("squirtle", 20, []string{"water"}, stats, nil)
+	ground := NewCombatant("diglett", 20, []string{"ground"}, stats, nil)
+	chart := TypeChart{"electric": {"water": 2, "ground": 0}}
+
+	thunderShock := Move{Name: "thunder-shock", Type: "electric", DamageClass: "special", Power: 40}
+	tackle := Move{Name: "tackle", Type: "normal", DamageClass: "physical", Power: 40}
+
+	damage := func(defender *Combatant, move Move) Hit {
+		// The same seed gives the same random factor and critical roll.
+		return Damage(attacker, defender, move, chart, rand.New(rand.NewPCG(3, 3)))
+	}
+
+	neutral := damage(ground, tackle)
+	if neutral.Damage <= 0 || neutral.Effectiveness != 1 {
+		t.Fatalf("Expected neutral damage, got %+v", neutral)
+	}
+	// STAB and a super effective hit stack: 1.5 * 2 = 3 times the damage.
+	super := damage(water, thunderShock)
+	if super.Effectiveness != 2 || super.Damage < 3*neutral.Damage-2 || super.Damage > 3*neutral.Damage+2 {
+		t.Errorf("Expected about %d damage, got %+v", 3*neutral.Damage, super)
+	}
+	if immune := damage(ground, thunderShock); immune.Damage != 0 || immune.Effectiveness != 0 {
+		t.Errorf("Expected no damage against ground, got %+v", immune)
+	}
+	if status := damage(water, Move{Name: "growl", DamageClass: "status"}); status.Damage != 0 {
+		t.Errorf("Expected status moves to deal no damage, got %+v", status)
+	}
+}
@@ -0,0 +1,32 @@
+package battle
+
+const (
+	// MaxEV is the most effort values a Pokémon can have in one stat.
+	MaxEV = 252
+	// MaxTotalEVs is the most effort values a Pokémon can have in all.
+	MaxTotalEVs = 510
+)
+
+// ExperienceYield returns the experience earned for defeating or catching a
+// wild Pokémon with the given base experience at level.
+func ExperienceYield(baseExperience, level int) int {
+	return max(1, baseExperience*level/7)
+}
+
+// AddEffort returns evs after gaining the effort values in yield, keeping
+// within MaxEV per stat and MaxTotalEVs overall.
+func AddEffort(evs, yield Stats) Stats {
+	total := 0
+	for _, name := range StatNames {
+		total += evs.Get(name)
+	}
+	for _, name := range StatNames {
+		gain := min(yield.Get(name), MaxEV-evs.Get(name), MaxTotalEVs-total)
+		if gain <= 0 {
+			continue
+		}
+		evs.Set(name, evs.Get(name)+gain)
+		total += gain
+	}
+	return evs
+}
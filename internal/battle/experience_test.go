@@ -0,0 +1,24 @@
+package battle
+
+import "testing"
+
+func TestExperienceYield(t *testing.T) {
+	if got := ExperienceYield(51, 7); got != 51 {
+		t.Errorf("Expected 51 experience from a level 7 rattata, got %d", got)
+	}
+	if got := ExperienceYield(0, 1); got != 1 {
+		t.Errorf("Expected at least 1 experience, got %d", got)
+	}
+}
+
+func TestAddEffort(t *testing.T) {
+	evs := AddEffort(Stats{Speed: 250}, Stats{Speed: 3, Attack: 1})
+	if evs.Speed != MaxEV || evs.Attack != 1 {
+		t.Errorf("Expected speed capped at %d and 1 attack, got %+v", MaxEV, evs)
+	}
+
+	evs = AddEffort(Stats{HP: 252, Attack: 252, Defense: 5}, Stats{Defense: 3, Speed: 2})
+	if evs.Defense != 6 || evs.Speed != 0 {
+		t.Errorf("Expected the total to stop at %d, got %+v", MaxTotalEVs, evs)
+	}
+}
@@ -0,0 +1,81 @@
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Match is a candidate name together with how far it is from the query.
+// Lower scores are better; 0 is an exact match.
+type Match struct {
+	Name  string
+	Score int
+}
+
+// Distance returns the Levenshtein edit distance between a and b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// MaxScore is the worst score a candidate may have to be suggested for query.
+func MaxScore(query string) int {
+	return max(1, len([]rune(query))/2)
+}
+
+// Suggest returns up to n candidates closest to query, best first, ignoring
+// case. A candidate containing the query scores at most 1, so partial names
+// such as "pika" still find "pikachu". Candidates scoring above MaxScore are
+// dropped.
+func Suggest(query string, candidates []string, n int) []Match {
+	query = strings.ToLower(query)
+	limit := MaxScore(query)
+
+	var matches []Match
+	for _, candidate := range candidates {
+		folded := strings.ToLower(candidate)
+		score := Distance(query, folded)
+		if score > 1 && query != "" && strings.Contains(folded, query) {
+			score = 1
+		}
+		if score <= limit {
+			matches = append(matches, Match{Name: candidate, Score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score < matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	if len(matches) > n {
+		matches = matches[:n]
+	}
+	return matches
+}
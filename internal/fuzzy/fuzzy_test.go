@@ -0,0 +1,75 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"pikachu", "pikachu", 0},
+		{"pikchu", "pikachu", 1},
+		{"charzard", "charizard", 1},
+		{"kitten", "sitting", 3},
+		{"", "mew", 3},
+		{"mew", "", 3},
+		{"flabébé", "flabebe", 2},
+	}
+
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Distance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"pikachu", "pichu", "raichu", "bulbasaur", "charizard", "charmander"}
+
+	matches := Suggest("charzard", candidates, 3)
+	if len(matches) == 0 || matches[0].Name != "charizard" {
+		t.Fatalf("Expected charizard as the best match, got %+v", matches)
+	}
+	if matches[0].Score != 1 {
+		t.Errorf("Expected a score of 1, got %d", matches[0].Score)
+	}
+
+	for _, match := range Suggest("pikchu", candidates, 10) {
+		if match.Name == "bulbasaur" {
+			t.Errorf("Unrelated names should not be suggested: %+v", match)
+		}
+	}
+}
+
+func TestSuggestSubstring(t *testing.T) {
+	matches := Suggest("char", []string{"charizard", "charmander", "squirtle"}, 5)
+	if len(matches) != 2 {
+		t.Fatalf("Expected both char Pokémon, got %+v", matches)
+	}
+	if matches[0].Name != "charizard" || matches[1].Name != "charmander" {
+		t.Errorf("Expected alphabetical order for equal scores, got %+v", matches)
+	}
+}
+
+func TestSuggestLimit(t *testing.T) {
+	matches := Suggest("a", []string{"a", "b", "c", "d"}, 2)
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches, got %+v", matches)
+	}
+	if matches[0].Name != "a" || matches[0].Score != 0 {
+		t.Errorf("Expected exact match first, got %+v", matches[0])
+	}
+}
+
+func TestSuggestIgnoresCase(t *testing.T) {
+	matches := Suggest("SPARKEY", []string{"Sparky", "pikachu"}, 5)
+	if len(matches) != 1 || matches[0].Name != "Sparky" || matches[0].Score != 1 {
+		t.Errorf("Expected Sparky one edit away, got %+v", matches)
+	}
+	if matches := Suggest("spark", []string{"MrSparky"}, 5); len(matches) != 1 || matches[0].Score != 1 {
+		t.Errorf("Expected a mixed-case candidate containing the query, got %+v", matches)
+	}
+}
@@ -0,0 +1,368 @@
+package lineedit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// maxListedCompletions caps how many candidates are printed when a Tab press
+// is ambiguous.
+const maxListedCompletions = 60
+
+// lineState is the line being edited along with the cursor position and the
+// history entry currently shown.
+type lineState struct {
+	prompt  string
+	buf     []rune
+	pos     int
+	histPos int
+	pending []rune
+}
+
+func (s *lineState) insert(text string) {
+	runes := []rune(text)
+	s.buf = append(s.buf[:s.pos], append(runes, s.buf[s.pos:]...)...)
+	s.pos += len(runes)
+}
+
+func (s *lineState) set(text string) {
+	s.buf = []rune(text)
+	s.pos = len(s.buf)
+}
+
+func (s *lineState) backspace() {
+	if s.pos == 0 {
+		return
+	}
+	s.buf = append(s.buf[:s.pos-1], s.buf[s.pos:]...)
+	s.pos--
+}
+
+func (s *lineState) deleteUnderCursor() {
+	if s.pos >= len(s.buf) {
+		return
+	}
+	s.buf = append(s.buf[:s.pos], s.buf[s.pos+1:]...)
+}
+
+func (s *lineState) deleteWord() {
+	start := s.pos
+	for start > 0 && s.buf[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && s.buf[start-1] != ' ' {
+		start--
+	}
+	s.buf = append(s.buf[:start], s.buf[s.pos:]...)
+	s.pos = start
+}
+
+// edit runs the interactive editing loop. The terminal must already be in
+// raw mode.
+func (e *Editor) edit(prompt string) (string, error) {
+	s := &lineState{prompt: prompt, histPos: len(e.history)}
+	e.refresh(s)
+
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(s.buf), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			s.deleteUnderCursor()
+		case keyBackspace, keyCtrlH:
+			s.backspace()
+		case keyCtrlA:
+			s.pos = 0
+		case keyCtrlE:
+			s.pos = len(s.buf)
+		case keyCtrlB:
+			s.pos = max(0, s.pos-1)
+		case keyCtrlF:
+			s.pos = min(len(s.buf), s.pos+1)
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = s.buf[s.pos:]
+			s.pos = 0
+		case keyCtrlW:
+			s.deleteWord()
+		case keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case keyCtrlP:
+			e.historyPrev(s)
+		case keyCtrlN:
+			e.historyNext(s)
+		case keyTab:
+			e.complete(s)
+		case keyCtrlR:
+			accepted, err := e.reverseSearch(s)
+			if err != nil {
+				return "", err
+			}
+			if accepted {
+				e.refresh(s)
+				fmt.Fprint(e.out, "\r\n")
+				return string(s.buf), nil
+			}
+		case keyEscape:
+			if err := e.escapeSequence(s); err != nil {
+				return "", err
+			}
+		default:
+			if unicode.IsPrint(r) {
+				s.insert(string(r))
+			}
+		}
+		e.refresh(s)
+	}
+}
+
+// escapeSequence handles the arrow, home, end and delete keys, which
+// terminals send as ESC [ or ESC O sequences. Other sequences, such as
+// arrows with modifiers, are read to their end and ignored.
+func (e *Editor) escapeSequence(s *lineState) error {
+	r, _, err := e.reader.ReadRune()
+	if err != nil {
+		return err
+	}
+	if r != '[' && r != 'O' {
+		return nil
+	}
+
+	// A CSI sequence is parameter and intermediate bytes followed by a
+	// final byte in the range @ to ~.
+	var params []rune
+	for {
+		r, _, err = e.reader.ReadRune()
+		if err != nil {
+			return err
+		}
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+		params = append(params, r)
+	}
+
+	switch code := string(params); {
+	case r == '~':
+		switch code {
+		case "1", "7":
+			s.pos = 0
+		case "4", "8":
+			s.pos = len(s.buf)
+		case "3":
+			s.deleteUnderCursor()
+		}
+	case code != "":
+	case r == 'A':
+		e.historyPrev(s)
+	case r == 'B':
+		e.historyNext(s)
+	case r == 'C':
+		s.pos = min(len(s.buf), s.pos+1)
+	case r == 'D':
+		s.pos = max(0, s.pos-1)
+	case r == 'H':
+		s.pos = 0
+	case r == 'F':
+		s.pos = len(s.buf)
+	}
+	return nil
+}
+
+func (e *Editor) historyPrev(s *lineState) {
+	if s.histPos == 0 {
+		return
+	}
+	if s.histPos == len(e.history) {
+		s.pending = append([]rune(nil), s.buf...)
+	}
+	s.histPos--
+	s.set(e.history[s.histPos])
+}
+
+func (e *Editor) historyNext(s *lineState) {
+	if s.histPos >= len(e.history) {
+		return
+	}
+	s.histPos++
+	if s.histPos == len(e.history) {
+		s.set(string(s.pending))
+		return
+	}
+	s.set(e.history[s.histPos])
+}
+
+// complete completes the word before the cursor. A single candidate is
+// inserted in full, several candidates are extended to their common prefix
+// and listed when no further progress can be made.
+func (e *Editor) complete(s *lineState) {
+	if e.Complete == nil {
+		return
+	}
+
+	before := string(s.buf[:s.pos])
+	word := before[strings.LastIndex(before, " ")+1:]
+
+	seen := make(map[string]bool)
+	var matches []string
+	for _, candidate := range e.Complete(before) {
+		if strings.HasPrefix(candidate, word) && !seen[candidate] {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+
+	switch len(matches) {
+	case 0:
+		fmt.Fprint(e.out, "\a")
+	case 1:
+		s.insert(matches[0][len(word):] + " ")
+	default:
+		if prefix := commonPrefix(matches); len(prefix) > len(word) {
+			s.insert(prefix[len(word):])
+			return
+		}
+		listed := matches
+		if len(listed) > maxListedCompletions {
+			listed = listed[:maxListedCompletions]
+		}
+		fmt.Fprintf(e.out, "\r\n%s", strings.Join(listed, "  "))
+		if len(matches) > len(listed) {
+			fmt.Fprintf(e.out, "  ...and %d more", len(matches)-len(listed))
+		}
+		fmt.Fprint(e.out, "\r\n")
+	}
+}
+
+func commonPrefix(words []string) string {
+	prefix := []rune(words[0])
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, string(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return string(prefix)
+}
+
+// reverseSearch runs an incremental search backwards through the history.
+// Enter accepts the match and reports true so the line is submitted; Ctrl-G
+// or Ctrl-C cancels; any other control key leaves the match in the buffer
+// for further editing. While nothing matches the query the prompt says so,
+// as readline's does, and Enter submits the line unchanged.
+func (e *Editor) reverseSearch(s *lineState) (bool, error) {
+	var query []rune
+	match := -1
+
+	search := func(from int) {
+		for i := min(from, len(e.history)-1); i >= 0; i-- {
+			if strings.Contains(e.history[i], string(query)) {
+				match = i
+				return
+			}
+		}
+		match = -1
+	}
+	matchText := func() string {
+		if match < 0 {
+			return ""
+		}
+		return e.history[match]
+	}
+
+	for {
+		prompt := "reverse-i-search"
+		if match < 0 && len(query) > 0 {
+			prompt = "failed reverse-i-search"
+		}
+		fmt.Fprintf(e.out, "\r(%s)`%s': %s\x1b[K", prompt, string(query), matchText())
+
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return false, err
+		}
+
+		switch r {
+		case keyCtrlR:
+			if match > 0 {
+				search(match - 1)
+			} else if match < 0 {
+				search(len(e.history) - 1)
+			}
+		case keyBackspace, keyCtrlH:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				search(len(e.history) - 1)
+			}
+		case keyCtrlG, keyCtrlC:
+			return false, nil
+		case '\r', '\n':
+			if match >= 0 {
+				s.set(matchText())
+			}
+			return true, nil
+		default:
+			if !unicode.IsPrint(r) {
+				if match >= 0 {
+					s.set(matchText())
+				}
+				return false, nil
+			}
+			query = append(query, r)
+			if match < 0 {
+				search(len(e.history) - 1)
+			} else {
+				search(match)
+			}
+		}
+	}
+}
+
+// refresh redraws the prompt and line and places the cursor.
+func (e *Editor) refresh(s *lineState) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "\r%s%s\x1b[K", s.prompt, string(s.buf))
+	if n := len(s.buf) - s.pos; n > 0 {
+		fmt.Fprintf(&b, "\x1b[%dD", n)
+	}
+	e.out.Write(b.Bytes())
+}
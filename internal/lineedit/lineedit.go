@@ -0,0 +1,133 @@
+// Package lineedit reads lines from a terminal with cursor movement,
+// history recall, reverse history search and tab completion. When input is
+// not a terminal it falls back to plain buffered line reading.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/term"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C.
+var ErrInterrupted = errors.New("interrupted")
+
+// MaxHistory is the number of history entries kept in memory and on disk.
+const MaxHistory = 1000
+
+// Completer returns the possible completions of the last word of line, the
+// text before the cursor. Candidates that don't start with that word are
+// ignored.
+type Completer func(line string) []string
+
+type Editor struct {
+	in          *os.File
+	reader      *bufio.Reader
+	out         io.Writer
+	history     []string
+	historyFile string
+	Complete    Completer
+}
+
+func New(in *os.File, out io.Writer) *Editor {
+	return &Editor{
+		in:     in,
+		reader: bufio.NewReader(in),
+		out:    out,
+	}
+}
+
+// LoadHistory reads previous entries from path and appends new entries to it
+// from now on. A missing file is not an error.
+func (e *Editor) LoadHistory(path string) error {
+	e.historyFile = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %v", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		e.addHistory(line)
+	}
+
+	// Keep the file from growing without bound.
+	if strings.Count(string(data), "\n") > MaxHistory {
+		content := strings.Join(e.history, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return fmt.Errorf("failed to trim history file: %v", err)
+		}
+	}
+	return nil
+}
+
+// AddHistory records line in the history and persists it when a history
+// file has been loaded.
+func (e *Editor) AddHistory(line string) error {
+	if !e.addHistory(line) || e.historyFile == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(e.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		return fmt.Errorf("failed to write history file: %v", err)
+	}
+	return nil
+}
+
+// History returns the in-memory history, oldest first.
+func (e *Editor) History() []string {
+	return e.history
+}
+
+func (e *Editor) addHistory(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(e.history) > 0 && e.history[len(e.history)-1] == line) {
+		return false
+	}
+	e.history = append(e.history, line)
+	if len(e.history) > MaxHistory {
+		e.history = e.history[len(e.history)-MaxHistory:]
+	}
+	return true
+}
+
+// ReadLine prints prompt and returns the next line of input without its
+// line ending. It returns io.EOF at the end of input or when Ctrl-D is
+// pressed on an empty line.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if e.in == nil || !term.IsTerminal(e.in.Fd()) {
+		fmt.Fprint(e.out, prompt)
+		return e.readPlain()
+	}
+
+	state, err := term.MakeRaw(e.in.Fd())
+	if err != nil {
+		fmt.Fprint(e.out, prompt)
+		return e.readPlain()
+	}
+	defer term.Restore(e.in.Fd(), state)
+
+	return e.edit(prompt)
+}
+
+func (e *Editor) readPlain() (string, error) {
+	line, err := e.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,230 @@
+package lineedit
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestEditor returns an editor reading keystrokes from input.
+func newTestEditor(input string) *Editor {
+	return &Editor{
+		reader: bufio.NewReader(strings.NewReader(input)),
+		out:    &bytes.Buffer{},
+	}
+}
+
+func TestEditKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain text", "map\r", "map"},
+		{"Backspace", "mapp\x7f\r", "map"},
+		{"Left arrow insert", "mp\x1b[Da\r", "map"},
+		{"Home and end", "ap\x1b[Hm\x1b[F!\r", "map!"},
+		{"Ctrl-A and Ctrl-E", "ap\x01m\x05!\r", "map!"},
+		{"Delete key", "mxap\x1b[H\x1b[C\x1b[3~\r", "map"},
+		{"Ctrl-K kills to end", "map extra\x01\x06\x06\x06\x0b\r", "map"},
+		{"Ctrl-U kills to start", "junk map\x01\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C\x15\r", "map"},
+		{"Ctrl-W deletes word", "explore forest\x17area\r", "explore area"},
+		{"Unicode", "pokémon\x7f\x7f\x7f\x7fe\r", "poke"},
+		{"Modified arrows ignored", "ma\x1b[1;5C\x1b[1;2Ap\r", "map"},
+		{"Unknown sequence ignored", "ma\x1b[200~p\x1bOQ\r", "map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEditor(tt.input)
+			line, err := e.edit("> ")
+			if err != nil {
+				t.Fatalf("edit returned an error: %v", err)
+			}
+			if line != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, line)
+			}
+		})
+	}
+}
+
+func TestEditCtrlDAndCtrlC(t *testing.T) {
+	if _, err := newTestEditor("\x04").edit("> "); err != io.EOF {
+		t.Errorf("Expected io.EOF for Ctrl-D on an empty line, got %v", err)
+	}
+	if _, err := newTestEditor("map\x03").edit("> "); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Expected ErrInterrupted for Ctrl-C, got %v", err)
+	}
+}
+
+func TestEditHistory(t *testing.T) {
+	e := newTestEditor("\x1b[A\x1b[A\r")
+	e.AddHistory("map")
+	e.AddHistory("explore pallet-town-area")
+
+	line, err := e.edit("> ")
+	if err != nil {
+		t.Fatalf("edit returned an error: %v", err)
+	}
+	if line != "map" {
+		t.Errorf("Expected two steps back in history to give 'map', got %q", line)
+	}
+
+	e = newTestEditor("cat\x1b[A\x1b[B\r")
+	e.AddHistory("map")
+	line, _ = e.edit("> ")
+	if line != "cat" {
+		t.Errorf("Expected the pending line to be restored, got %q", line)
+	}
+}
+
+func TestEditReverseSearch(t *testing.T) {
+	e := newTestEditor("\x12exp\r")
+	e.AddHistory("explore pallet-town-area")
+	e.AddHistory("map")
+	e.AddHistory("catch pikachu")
+
+	line, err := e.edit("> ")
+	if err != nil {
+		t.Fatalf("edit returned an error: %v", err)
+	}
+	if line != "explore pallet-town-area" {
+		t.Errorf("Expected reverse search to find the explore command, got %q", line)
+	}
+
+	// A second Ctrl-R moves to an older match; Ctrl-E accepts it for editing.
+	e = newTestEditor("\x12a\x12\x05!\r")
+	e.AddHistory("map")
+	e.AddHistory("catch pikachu")
+	line, _ = e.edit("> ")
+	if line != "map!" {
+		t.Errorf("Expected the older match to be edited, got %q", line)
+	}
+}
+
+func TestEditReverseSearchFails(t *testing.T) {
+	e := newTestEditor("\x12abz\r")
+	e.AddHistory("abc")
+
+	line, err := e.edit("> ")
+	if err != nil {
+		t.Fatalf("edit returned an error: %v", err)
+	}
+	if line != "" {
+		t.Errorf("Expected a failed search to match nothing, got %q", line)
+	}
+	if out := e.out.(*bytes.Buffer).String(); !strings.Contains(out, "(failed reverse-i-search)`abz'") {
+		t.Errorf("Expected the prompt to show the search failed, got %q", out)
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	if prefix := commonPrefix([]string{"flabébé", "flabéx"}); prefix != "flabé" {
+		t.Errorf("Expected the prefix to end on a whole rune, got %q", prefix)
+	}
+}
+
+func TestEditCompletion(t *testing.T) {
+	complete := func(line string) []string {
+		return []string{"map", "mapb", "explore", "exit"}
+	}
+
+	e := newTestEditor("exp\t\r")
+	e.Complete = complete
+	line, _ := e.edit("> ")
+	if line != "explore " {
+		t.Errorf("Expected a unique completion, got %q", line)
+	}
+
+	e = newTestEditor("ma\tb\r")
+	e.Complete = complete
+	line, _ = e.edit("> ")
+	if line != "mapb" {
+		t.Errorf("Expected completion to the common prefix, got %q", line)
+	}
+
+	e = newTestEditor("ex\t\r")
+	e.Complete = complete
+	line, _ = e.edit("> ")
+	if line != "ex" {
+		t.Errorf("Ambiguous completion should leave the line alone, got %q", line)
+	}
+	if out := e.out.(*bytes.Buffer).String(); !strings.Contains(out, "exit  explore") {
+		t.Errorf("Expected candidates to be listed, got %q", out)
+	}
+}
+
+func TestHistoryPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	e := newTestEditor("")
+	if err := e.LoadHistory(path); err != nil {
+		t.Fatalf("LoadHistory on a missing file returned an error: %v", err)
+	}
+	e.AddHistory("map")
+	e.AddHistory("map")
+	e.AddHistory("  ")
+	e.AddHistory("catch pikachu")
+
+	e = newTestEditor("")
+	if err := e.LoadHistory(path); err != nil {
+		t.Fatalf("LoadHistory returned an error: %v", err)
+	}
+	history := e.History()
+	if len(history) != 2 || history[0] != "map" || history[1] != "catch pikachu" {
+		t.Errorf("Unexpected history after reload: %v", history)
+	}
+}
+
+func TestHistoryTrimmed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	var b strings.Builder
+	for i := 0; i < MaxHistory+10; i++ {
+		b.WriteString("cmd")
+		b.WriteString(strings.Repeat("x", i%7))
+		b.WriteString(string(rune('a' + i%26)))
+		b.WriteString("\n")
+	}
+	os.WriteFile(path, []byte(b.String()), 0o600)
+
+	e := newTestEditor("")
+	if err := e.LoadHistory(path); err != nil {
+		t.Fatalf("LoadHistory returned an error: %v", err)
+	}
+	if len(e.History()) > MaxHistory {
+		t.Errorf("Expected at most %d entries, got %d", MaxHistory, len(e.History()))
+	}
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines > MaxHistory {
+		t.Errorf("Expected the history file to be trimmed, it has %d lines", lines)
+	}
+}
+
+func TestReadLineNotTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "input")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	f.WriteString("map\nexit")
+	f.Seek(0, io.SeekStart)
+
+	out := &bytes.Buffer{}
+	e := New(f, out)
+	for _, expected := range []string{"map", "exit"} {
+		line, err := e.ReadLine("> ")
+		if err != nil {
+			t.Fatalf("ReadLine returned an error: %v", err)
+		}
+		if line != expected {
+			t.Errorf("Expected %q, got %q", expected, line)
+		}
+	}
+	if _, err := e.ReadLine("> "); err != io.EOF {
+		t.Errorf("Expected io.EOF at end of input, got %v", err)
+	}
+}
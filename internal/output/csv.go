@@ -0,0 +1,90 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// writeCSV writes one row per record. Nested objects are flattened into
+// dotted column names (stats.hp), lists of scalars are joined with ";" and
+// lists of objects are embedded as JSON. Columns appear in the order they
+// are first seen.
+func writeCSV(w io.Writer, root node) error {
+	records, isList := root.([]node)
+	if !isList {
+		records = []node{root}
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	var rows []map[string]string
+	for _, record := range records {
+		row := make(map[string]string)
+		var order []string
+		flattenCSV("", record, row, &order)
+		for _, column := range order {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func flattenCSV(prefix string, n node, row map[string]string, order *[]string) {
+	set := func(column, value string) {
+		if _, exists := row[column]; !exists {
+			*order = append(*order, column)
+		}
+		row[column] = value
+	}
+
+	switch v := n.(type) {
+	case *object:
+		for _, f := range v.fields {
+			column := f.key
+			if prefix != "" {
+				column = prefix + "." + f.key
+			}
+			flattenCSV(column, f.value, row, order)
+		}
+	case []node:
+		column := prefix
+		if column == "" {
+			column = "value"
+		}
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if !isScalar(item) {
+				set(column, compactJSON(v))
+				return
+			}
+			values = append(values, scalarString(item))
+		}
+		set(column, strings.Join(values, ";"))
+	default:
+		column := prefix
+		if column == "" {
+			column = "value"
+		}
+		set(column, scalarString(v))
+	}
+}
@@ -0,0 +1,194 @@
+// Package output encodes command results as JSON, YAML or CSV. Field names
+// come from the json tags of the values being written, so every format uses
+// the same stable names.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+)
+
+// Formats lists every supported format.
+var Formats = []Format{Text, JSON, YAML, CSV}
+
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format '%s', expected text, json, yaml or csv", name)
+}
+
+// Write encodes v to w in the given structured format. v is usually a
+// slice of records; a single struct is written as one record. The text
+// format is rendered by the caller and is rejected here.
+func Write(w io.Writer, format Format, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %v", err)
+	}
+
+	switch format {
+	case JSON:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return fmt.Errorf("failed to encode output: %v", err)
+		}
+		buf.WriteByte('\n')
+		_, err := w.Write(buf.Bytes())
+		return err
+	case YAML:
+		root, err := decodeOrdered(data)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, encodeYAML(root))
+		return err
+	case CSV:
+		root, err := decodeOrdered(data)
+		if err != nil {
+			return err
+		}
+		return writeCSV(w, root)
+	}
+	return fmt.Errorf("format %s can't be written as structured output", format)
+}
+
+// node is a decoded JSON value that keeps object keys in their original
+// order: an *object, a []node, or a scalar (string, json.Number, bool, nil).
+type node any
+
+type field struct {
+	key   string
+	value node
+}
+
+type object struct {
+	fields []field
+}
+
+func decodeOrdered(data []byte) (node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := decodeNode(dec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %v", err)
+	}
+	return n, nil
+}
+
+func decodeNode(dec *json.Decoder) (node, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := &object{}
+		for dec.More() {
+			keyToken, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.fields = append(obj.fields, field{key: keyToken.(string), value: value})
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		items := []node{}
+		for dec.More() {
+			item, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	}
+	return token, nil
+}
+
+// scalarString renders a scalar node as plain text.
+func scalarString(n node) string {
+	switch v := n.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	return fmt.Sprint(n)
+}
+
+func isScalar(n node) bool {
+	switch n.(type) {
+	case *object, []node:
+		return false
+	}
+	return true
+}
+
+// compactJSON renders a node back to single-line JSON.
+func compactJSON(n node) string {
+	var b strings.Builder
+	writeCompactJSON(&b, n)
+	return b.String()
+}
+
+func writeCompactJSON(b *strings.Builder, n node) {
+	switch v := n.(type) {
+	case *object:
+		b.WriteByte('{')
+		for i, f := range v.fields {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.key)
+			b.Write(key)
+			b.WriteByte(':')
+			writeCompactJSON(b, f.value)
+		}
+		b.WriteByte('}')
+	case []node:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCompactJSON(b, item)
+		}
+		b.WriteByte(']')
+	case string:
+		encoded, _ := json.Marshal(v)
+		b.Write(encoded)
+	case nil:
+		b.WriteString("null")
+	default:
+		b.WriteString(scalarString(v))
+	}
+}
@@ -0,0 +1,160 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testStat struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+type testRecord struct {
+	Name    string         `json:"name"`
+	ID      int            `json:"id"`
+	Types   []string       `json:"types"`
+	Stats   map[string]int `json:"stats"`
+	Moves   []testStat     `json:"moves"`
+	Comment string         `json:"comment,omitempty"`
+}
+
+var testRecords = []testRecord{
+	{
+		Name:  "pikachu",
+		ID:    25,
+		Types: []string{"electric"},
+		Stats: map[string]int{"hp": 35, "speed": 90},
+		Moves: []testStat{{Name: "thunder-shock", Value: 40}},
+	},
+	{
+		Name:    "mr-mime",
+		ID:      122,
+		Types:   []string{"psychic", "fairy"},
+		Stats:   map[string]int{"hp": 40, "speed": 90},
+		Comment: "yes: really",
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"text", "json", "yaml", "csv"} {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("ParseFormat(%q) returned an error: %v", name, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSON, testRecords); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	var decoded []testRecord
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 2 || decoded[1].Stats["hp"] != 40 {
+		t.Errorf("Unexpected JSON round trip: %+v", decoded)
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, YAML, testRecords); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	expected := `- name: pikachu
+  id: 25
+  types:
+    - electric
+  stats:
+    hp: 35
+    speed: 90
+  moves:
+    - name: thunder-shock
+      value: 40
+- name: mr-mime
+  id: 122
+  types:
+    - psychic
+    - fairy
+  stats:
+    hp: 40
+    speed: 90
+  moves: null
+  comment: "yes: really"
+`
+	if buf.String() != expected {
+		t.Errorf("Unexpected YAML:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestYAMLQuoting(t *testing.T) {
+	tests := map[string]bool{
+		"pikachu": false,
+		"":        true,
+		"yes":     true,
+		"12":      true,
+		"- dash":  true,
+		"a: b":    true,
+		" pad":    true,
+		"mr-mime": false,
+	}
+	for value, quoted := range tests {
+		if got := needsQuotes(value); got != quoted {
+			t.Errorf("needsQuotes(%q) = %v, expected %v", value, got, quoted)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, testRecords); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got:\n%s", buf.String())
+	}
+	if lines[0] != "name,id,types,stats.hp,stats.speed,moves,comment" {
+		t.Errorf("Unexpected header: %s", lines[0])
+	}
+	if lines[1] != `pikachu,25,electric,35,90,"[{""name"":""thunder-shock"",""value"":40}]",` {
+		t.Errorf("Unexpected first row: %s", lines[1])
+	}
+	if lines[2] != "mr-mime,122,psychic;fairy,40,90,,yes: really" {
+		t.Errorf("Unexpected second row: %s", lines[2])
+	}
+}
+
+func TestWriteSingleRecord(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, testRecords[0]); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 {
+		t.Errorf("Expected a header and one row, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := Write(&buf, YAML, []string{}); err != nil {
+		t.Fatalf("Write returned an error: %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("Expected an empty YAML list, got %q", buf.String())
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, Text, testRecords); err == nil {
+		t.Error("Expected the text format to be rejected")
+	}
+}
@@ -0,0 +1,147 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+)
+
+// encodeYAML renders a node as a block-style YAML document.
+func encodeYAML(n node) string {
+	var b strings.Builder
+	switch v := n.(type) {
+	case *object:
+		if len(v.fields) == 0 {
+			return "{}\n"
+		}
+		writeYAMLObject(&b, v, 0)
+	case []node:
+		if len(v) == 0 {
+			return "[]\n"
+		}
+		writeYAMLArray(&b, v, 0)
+	default:
+		b.WriteString(yamlScalar(v))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func writeYAMLObject(b *strings.Builder, obj *object, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, f := range obj.fields {
+		b.WriteString(pad)
+		b.WriteString(yamlKey(f.key))
+		b.WriteByte(':')
+		writeYAMLValue(b, f.value, indent+2)
+	}
+}
+
+func writeYAMLArray(b *strings.Builder, items []node, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, item := range items {
+		if isScalar(item) || isEmpty(item) {
+			b.WriteString(pad)
+			b.WriteString("- ")
+			b.WriteString(inlineYAML(item))
+			b.WriteByte('\n')
+			continue
+		}
+
+		// Render the item one level deeper, then put the dash in place of
+		// the indentation of its first line.
+		var nested strings.Builder
+		switch v := item.(type) {
+		case *object:
+			writeYAMLObject(&nested, v, indent+2)
+		case []node:
+			writeYAMLArray(&nested, v, indent+2)
+		}
+		b.WriteString(pad)
+		b.WriteString("- ")
+		b.WriteString(nested.String()[indent+2:])
+	}
+}
+
+// writeYAMLValue writes the value that follows "key:".
+func writeYAMLValue(b *strings.Builder, n node, indent int) {
+	if isScalar(n) || isEmpty(n) {
+		b.WriteByte(' ')
+		b.WriteString(inlineYAML(n))
+		b.WriteByte('\n')
+		return
+	}
+
+	b.WriteByte('\n')
+	switch v := n.(type) {
+	case *object:
+		writeYAMLObject(b, v, indent)
+	case []node:
+		writeYAMLArray(b, v, indent)
+	}
+}
+
+func isEmpty(n node) bool {
+	switch v := n.(type) {
+	case *object:
+		return len(v.fields) == 0
+	case []node:
+		return len(v) == 0
+	}
+	return false
+}
+
+func inlineYAML(n node) string {
+	switch n.(type) {
+	case *object:
+		return "{}"
+	case []node:
+		return "[]"
+	}
+	return yamlScalar(n)
+}
+
+func yamlKey(key string) string {
+	if needsQuotes(key) {
+		return strconv.Quote(key)
+	}
+	return key
+}
+
+func yamlScalar(n node) string {
+	switch v := n.(type) {
+	case nil:
+		return "null"
+	case string:
+		if needsQuotes(v) {
+			return strconv.Quote(v)
+		}
+		return v
+	}
+	return scalarString(n)
+}
+
+// needsQuotes reports whether s would be misread as a plain YAML scalar.
+func needsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", "y", "n":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.ContainsAny(s, "\n\t\r") {
+		return true
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
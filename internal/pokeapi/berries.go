@@ -0,0 +1,39 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+type BerryFlavorMap struct {
+	Potency int              `json:"potency"`
+	Flavor  NamedAPIResource `json:"flavor"`
+}
+
+type Berry struct {
+	ID               int              `json:"id"`
+	Name             string           `json:"name"`
+	GrowthTime       int              `json:"growth_time"`
+	MaxHarvest       int              `json:"max_harvest"`
+	NaturalGiftPower int              `json:"natural_gift_power"`
+	Size             int              `json:"size"`
+	Smoothness       int              `json:"smoothness"`
+	SoilDryness      int              `json:"soil_dryness"`
+	Firmness         NamedAPIResource `json:"firmness"`
+	Flavors          []BerryFlavorMap `json:"flavors"`
+	Item             NamedAPIResource `json:"item"`
+	NaturalGiftType  NamedAPIResource `json:"natural_gift_type"`
+}
+
+func GetBerry(cache *pokecache.Cache, berryName string) (*Berry, error) {
+	if berryName == "" {
+		return nil, fmt.Errorf("berry name cannot be empty when fetching berry info")
+	}
+
+	var berry Berry
+	if err := fetchJSON(cache, fmt.Sprintf("%s/berry/%s", baseURL, berryName), &berry); err != nil {
+		return nil, err
+	}
+	return &berry, nil
+}
@@ -0,0 +1,31 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetBerry_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/berry/cheri", []byte(`{
+		"id": 1,
+		"name": "cheri",
+		"growth_time": 3,
+		"max_harvest": 5,
+		"firmness": {"name": "soft", "url": ""},
+		"flavors": [
+			{"potency": 10, "flavor": {"name": "spicy", "url": ""}},
+			{"potency": 0, "flavor": {"name": "dry", "url": ""}}
+		]
+	}`))
+
+	berry, err := GetBerry(cache, "cheri")
+	if err != nil {
+		t.Fatalf("GetBerry returned an error: %v", err)
+	}
+	if berry.GrowthTime != 3 || berry.Firmness.Name != "soft" || len(berry.Flavors) != 2 {
+		t.Errorf("Unexpected berry decoded: %+v", berry)
+	}
+}
@@ -0,0 +1,148 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+type Encounter struct {
+	MinLevel        int                `json:"min_level"`
+	MaxLevel        int                `json:"max_level"`
+	ConditionValues []NamedAPIResource `json:"condition_values"`
+	Chance          int                `json:"chance"`
+	Method          NamedAPIResource   `json:"method"`
+}
+
+type VersionEncounterDetail struct {
+	Version          NamedAPIResource `json:"version"`
+	MaxChance        int              `json:"max_chance"`
+	EncounterDetails []Encounter      `json:"encounter_details"`
+}
+
+// EncounterSummary describes how a Pokémon can be met in a location area for
+// a single game version, encounter method and set of conditions. Level
+// ranges and chances of the individual encounter slots are combined.
+type EncounterSummary struct {
+	Pokemon      string   `json:"pokemon"`
+	LocationArea string   `json:"location_area"`
+	Version      string   `json:"version"`
+	Method       string   `json:"method"`
+	MinLevel     int      `json:"min_level"`
+	MaxLevel     int      `json:"max_level"`
+	Chance       int      `json:"chance"`
+	Conditions   []string `json:"conditions"`
+}
+
+// MethodRate is the rate at which an encounter method triggers in a location
+// area for a game version.
+type MethodRate struct {
+	Version string `json:"version"`
+	Method  string `json:"method"`
+	Rate    int    `json:"rate"`
+}
+
+func GetLocationArea(cache *pokecache.Cache, locationAreaName string) (*LocationAreaDetail, error) {
+	if locationAreaName == "" {
+		return nil, fmt.Errorf("location area name cannot be empty when fetching location area")
+	}
+
+	var locationDetail LocationAreaDetail
+	if err := fetchJSON(cache, fmt.Sprintf("%s/location-area/%s", baseURL, locationAreaName), &locationDetail); err != nil {
+		return nil, err
+	}
+	return &locationDetail, nil
+}
+
+// Encounters summarizes the Pokémon encounters of the area. When version is
+// non-empty only encounters for that game version are returned.
+func (d *LocationAreaDetail) Encounters(version string) []EncounterSummary {
+	var summaries []EncounterSummary
+	for _, encounter := range d.PokemonEncounters {
+		summaries = append(summaries, summarizeEncounters(encounter.Pokemon.Name, d.Name, encounter.VersionDetails, version)...)
+	}
+	return summaries
+}
+
+// MethodRates lists the encounter method rates of the area, optionally
+// restricted to a single game version.
+func (d *LocationAreaDetail) MethodRates(version string) []MethodRate {
+	var rates []MethodRate
+	for _, methodRate := range d.EncounterMethodRates {
+		for _, detail := range methodRate.VersionDetails {
+			if version != "" && detail.Version.Name != version {
+				continue
+			}
+			rates = append(rates, MethodRate{
+				Version: detail.Version.Name,
+				Method:  methodRate.EncounterMethod.Name,
+				Rate:    detail.Rate,
+			})
+		}
+	}
+	return rates
+}
+
+func summarizeEncounters(pokemon, area string, details []VersionEncounterDetail, version string) []EncounterSummary {
+	var summaries []EncounterSummary
+	index := make(map[string]int)
+
+	for _, versionDetail := range details {
+		if version != "" && versionDetail.Version.Name != version {
+			continue
+		}
+		for _, encounter := range versionDetail.EncounterDetails {
+			conditions := make([]string, 0, len(encounter.ConditionValues))
+			for _, condition := range encounter.ConditionValues {
+				conditions = append(conditions, condition.Name)
+			}
+
+			key := strings.Join([]string{versionDetail.Version.Name, encounter.Method.Name, strings.Join(conditions, ",")}, "|")
+			if i, exists := index[key]; exists {
+				summary := &summaries[i]
+				summary.MinLevel = min(summary.MinLevel, encounter.MinLevel)
+				summary.MaxLevel = max(summary.MaxLevel, encounter.MaxLevel)
+				summary.Chance += encounter.Chance
+				continue
+			}
+
+			index[key] = len(summaries)
+			summaries = append(summaries, EncounterSummary{
+				Pokemon:      pokemon,
+				LocationArea: area,
+				Version:      versionDetail.Version.Name,
+				Method:       encounter.Method.Name,
+				MinLevel:     encounter.MinLevel,
+				MaxLevel:     encounter.MaxLevel,
+				Chance:       encounter.Chance,
+				Conditions:   conditions,
+			})
+		}
+	}
+	return summaries
+}
+
+type LocationAreaEncounter struct {
+	LocationArea   NamedAPIResource         `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// GetPokemonEncounters returns the location areas where a Pokémon can be
+// encountered. When version is non-empty only that game version is included.
+func GetPokemonEncounters(cache *pokecache.Cache, pokemonName, version string) ([]EncounterSummary, error) {
+	if pokemonName == "" {
+		return nil, fmt.Errorf("pokemon name cannot be empty when fetching pokemon encounters")
+	}
+
+	var areas []LocationAreaEncounter
+	if err := fetchJSON(cache, fmt.Sprintf("%s/pokemon/%s/encounters", baseURL, pokemonName), &areas); err != nil {
+		return nil, err
+	}
+
+	var summaries []EncounterSummary
+	for _, area := range areas {
+		summaries = append(summaries, summarizeEncounters(pokemonName, area.LocationArea.Name, area.VersionDetails, version)...)
+	}
+	return summaries, nil
+}
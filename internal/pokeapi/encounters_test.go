@@ -0,0 +1,118 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+const viridianForestJSON = `{
+	"id": 321,
+	"name": "viridian-forest-area",
+	"location": {"name": "viridian-forest", "url": ""},
+	"encounter_method_rates": [
+		{"encounter_method": {"name": "walk", "url": ""}, "version_details": [
+			{"rate": 8, "version": {"name": "red", "url": ""}},
+			{"rate": 8, "version": {"name": "blue", "url": ""}}
+		]}
+	],
+	"pokemon_encounters": [
+		{"pokemon": {"name": "caterpie", "url": ""}, "version_details": [
+			{"version": {"name": "red", "url": ""}, "max_chance": 50, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 5, "condition_values": [], "method": {"name": "walk", "url": ""}},
+				{"min_level": 5, "max_level": 5, "chance": 45, "condition_values": [], "method": {"name": "walk", "url": ""}}
+			]},
+			{"version": {"name": "blue", "url": ""}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 5, "condition_values": [], "method": {"name": "walk", "url": ""}}
+			]}
+		]},
+		{"pokemon": {"name": "pikachu", "url": ""}, "version_details": [
+			{"version": {"name": "yellow", "url": ""}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 5, "chance": 5, "condition_values": [{"name": "time-morning", "url": ""}], "method": {"name": "walk", "url": ""}}
+			]}
+		]}
+	]
+}`
+
+func TestLocationAreaEncounters(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/location-area/viridian-forest-area", []byte(viridianForestJSON))
+
+	area, err := GetLocationArea(cache, "viridian-forest-area")
+	if err != nil {
+		t.Fatalf("GetLocationArea returned an error: %v", err)
+	}
+
+	all := area.Encounters("")
+	if len(all) != 3 {
+		t.Fatalf("Expected 3 encounter summaries, got %d: %+v", len(all), all)
+	}
+
+	red := area.Encounters("red")
+	if len(red) != 1 {
+		t.Fatalf("Expected 1 red encounter summary, got %d: %+v", len(red), red)
+	}
+	caterpie := red[0]
+	if caterpie.Pokemon != "caterpie" || caterpie.MinLevel != 3 || caterpie.MaxLevel != 5 || caterpie.Chance != 50 {
+		t.Errorf("Encounter slots were not combined correctly: %+v", caterpie)
+	}
+	if caterpie.LocationArea != "viridian-forest-area" {
+		t.Errorf("Expected location area to be recorded, got %q", caterpie.LocationArea)
+	}
+
+	yellow := area.Encounters("yellow")
+	if len(yellow) != 1 || len(yellow[0].Conditions) != 1 || yellow[0].Conditions[0] != "time-morning" {
+		t.Errorf("Expected conditions to be kept, got %+v", yellow)
+	}
+}
+
+func TestLocationAreaMethodRates(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/location-area/viridian-forest-area", []byte(viridianForestJSON))
+
+	area, err := GetLocationArea(cache, "viridian-forest-area")
+	if err != nil {
+		t.Fatalf("GetLocationArea returned an error: %v", err)
+	}
+
+	if rates := area.MethodRates(""); len(rates) != 2 {
+		t.Errorf("Expected 2 method rates, got %+v", rates)
+	}
+	rates := area.MethodRates("red")
+	if len(rates) != 1 || rates[0].Method != "walk" || rates[0].Rate != 8 {
+		t.Errorf("Unexpected red method rates: %+v", rates)
+	}
+}
+
+func TestGetPokemonEncounters_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/pokemon/pikachu/encounters", []byte(`[
+		{"location_area": {"name": "viridian-forest-area", "url": ""}, "version_details": [
+			{"version": {"name": "yellow", "url": ""}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 5, "chance": 5, "condition_values": [], "method": {"name": "walk", "url": ""}}
+			]}
+		]},
+		{"location_area": {"name": "kanto-power-plant-area", "url": ""}, "version_details": [
+			{"version": {"name": "red", "url": ""}, "max_chance": 25, "encounter_details": [
+				{"min_level": 20, "max_level": 24, "chance": 25, "condition_values": [], "method": {"name": "walk", "url": ""}}
+			]}
+		]}
+	]`))
+
+	all, err := GetPokemonEncounters(cache, "pikachu", "")
+	if err != nil {
+		t.Fatalf("GetPokemonEncounters returned an error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 encounter summaries, got %+v", all)
+	}
+
+	red, err := GetPokemonEncounters(cache, "pikachu", "red")
+	if err != nil {
+		t.Fatalf("GetPokemonEncounters returned an error: %v", err)
+	}
+	if len(red) != 1 || red[0].LocationArea != "kanto-power-plant-area" || red[0].Pokemon != "pikachu" {
+		t.Errorf("Unexpected red encounters: %+v", red)
+	}
+}
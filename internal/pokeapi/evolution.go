@@ -0,0 +1,112 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// EvolutionDetail is one way a Pokémon can evolve: a trigger and the
+// conditions that must all hold when it happens. Unset conditions are nil,
+// zero or empty.
+type EvolutionDetail struct {
+	Trigger  NamedAPIResource  `json:"trigger"` // e.g. level-up, trade, use-item
+	MinLevel *int              `json:"min_level"`
+	Item     *NamedAPIResource `json:"item"`
+	HeldItem *NamedAPIResource `json:"held_item"`
+	// TradeSpecies is the Pokémon that must be traded away in exchange.
+	TradeSpecies  *NamedAPIResource `json:"trade_species"`
+	Gender        *int              `json:"gender"`      // 1 for female, 2 for male
+	TimeOfDay     string            `json:"time_of_day"` // day or night
+	KnownMove     *NamedAPIResource `json:"known_move"`
+	KnownMoveType *NamedAPIResource `json:"known_move_type"`
+	Location      *NamedAPIResource `json:"location"`
+	MinHappiness  *int              `json:"min_happiness"`
+	MinBeauty     *int              `json:"min_beauty"`
+	MinAffection  *int              `json:"min_affection"`
+	PartySpecies  *NamedAPIResource `json:"party_species"`
+	PartyType     *NamedAPIResource `json:"party_type"`
+	// RelativePhysicalStats compares Attack with Defense: 1 for greater, 0
+	// for equal and -1 for less.
+	RelativePhysicalStats *int `json:"relative_physical_stats"`
+	NeedsOverworldRain    bool `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool `json:"turn_upside_down"`
+}
+
+// levelOnly reports whether reaching MinLevel is all the detail asks for.
+func (d EvolutionDetail) levelOnly() bool {
+	return d.Trigger.Name == "level-up" && d.MinLevel != nil &&
+		d.Item == nil && d.HeldItem == nil && d.TradeSpecies == nil &&
+		d.Gender == nil && d.TimeOfDay == "" &&
+		d.KnownMove == nil && d.KnownMoveType == nil && d.Location == nil &&
+		d.MinHappiness == nil && d.MinBeauty == nil && d.MinAffection == nil &&
+		d.PartySpecies == nil && d.PartyType == nil &&
+		d.RelativePhysicalStats == nil && !d.NeedsOverworldRain && !d.TurnUpsideDown
+}
+
+// ChainLink is a species in an evolution chain with the species it can
+// evolve into.
+type ChainLink struct {
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"` // How this species is reached
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+// GetEvolutionChain fetches the chain at url, as linked from a species.
+func GetEvolutionChain(cache *pokecache.Cache, url string) (*EvolutionChain, error) {
+	if url == "" {
+		return nil, fmt.Errorf("evolution chain URL cannot be empty when fetching evolution chain info")
+	}
+
+	var chain EvolutionChain
+	if err := fetchJSON(cache, url, &chain); err != nil {
+		return nil, err
+	}
+	return &chain, nil
+}
+
+// Find returns the link for species within the chain, or nil.
+func (c *ChainLink) Find(species string) *ChainLink {
+	if c.Species.Name == species {
+		return c
+	}
+	for i := range c.EvolvesTo {
+		if link := c.EvolvesTo[i].Find(species); link != nil {
+			return link
+		}
+	}
+	return nil
+}
+
+// LevelEvolution returns the species this link evolves into by levelling up
+// to level, if any. Evolutions with conditions besides a minimum level,
+// such as a gender, time of day or known move, are not considered.
+func (c *ChainLink) LevelEvolution(level int) (string, bool) {
+	for _, next := range c.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if detail.levelOnly() && level >= *detail.MinLevel {
+				return next.Species.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// TradeEvolution returns the species this link evolves into when traded, if
+// any. Trades that need a held item or a particular partner are not
+// considered.
+func (c *ChainLink) TradeEvolution() (string, bool) {
+	for _, next := range c.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if detail.Trigger.Name == "trade" && detail.HeldItem == nil && detail.TradeSpecies == nil {
+				return next.Species.Name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,106 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetEvolutionChain_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	url := "https://pokeapi.co/api/v2/evolution-chain/10/"
+	cache.Add(url, []byte(`{
+		"id": 10,
+		"chain": {
+			"species": {"name": "pichu"},
+			"evolution_details": [],
+			"evolves_to": [{
+				"species": {"name": "pikachu"},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 220, "min_level": null}],
+				"evolves_to": [{
+					"species": {"name": "raichu"},
+					"evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}],
+					"evolves_to": []
+				}]
+			}]
+		}
+	}`))
+
+	chain, err := GetEvolutionChain(cache, url)
+	if err != nil {
+		t.Fatalf("GetEvolutionChain returned an error: %v", err)
+	}
+	pikachu := chain.Chain.Find("pikachu")
+	if pikachu == nil || len(pikachu.EvolvesTo) != 1 {
+		t.Fatalf("Expected to find pikachu in the chain, got %+v", pikachu)
+	}
+	if next, ok := pikachu.LevelEvolution(100); ok {
+		t.Errorf("Raichu needs a stone, but pikachu evolved into %s by level", next)
+	}
+	if chain.Chain.Find("mew") != nil {
+		t.Error("Expected no link for a species outside the chain")
+	}
+}
+
+func TestChainLink_LevelEvolution(t *testing.T) {
+	sixteen := 16
+	link := ChainLink{
+		Species: NamedAPIResource{Name: "charmander"},
+		EvolvesTo: []ChainLink{{
+			Species:          NamedAPIResource{Name: "charmeleon"},
+			EvolutionDetails: []EvolutionDetail{{Trigger: NamedAPIResource{Name: "level-up"}, MinLevel: &sixteen}},
+		}},
+	}
+	if _, ok := link.LevelEvolution(15); ok {
+		t.Error("Expected no evolution before level 16")
+	}
+	if next, ok := link.LevelEvolution(16); !ok || next != "charmeleon" {
+		t.Errorf("Expected charmeleon at level 16, got %q", next)
+	}
+}
+
+func TestChainLink_LevelEvolutionSkipsConditions(t *testing.T) {
+	conditions := []string{
+		`"gender": 1`,
+		`"time_of_day": "night"`,
+		`"relative_physical_stats": 1`,
+		`"needs_overworld_rain": true`,
+		`"turn_upside_down": true`,
+		`"known_move": {"name": "ancient-power"}`,
+		`"min_happiness": 160`,
+		`"location": {"name": "eterna-forest"}`,
+	}
+	for _, condition := range conditions {
+		var link ChainLink
+		body := `{"species": {"name": "tyrogue"}, "evolves_to": [{
+			"species": {"name": "hitmonlee"},
+			"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 20, ` + condition + `}]
+		}]}`
+		if err := json.Unmarshal([]byte(body), &link); err != nil {
+			t.Fatalf("Failed to decode the chain with %s: %v", condition, err)
+		}
+		if next, ok := link.LevelEvolution(100); ok {
+			t.Errorf("Expected no level evolution with %s, got %s", condition, next)
+		}
+	}
+}
+
+func TestChainLink_TradeEvolution(t *testing.T) {
+	link := ChainLink{
+		Species: NamedAPIResource{Name: "kadabra"},
+		EvolvesTo: []ChainLink{{
+			Species:          NamedAPIResource{Name: "alakazam"},
+			EvolutionDetails: []EvolutionDetail{{Trigger: NamedAPIResource{Name: "trade"}}},
+		}},
+	}
+	if next, ok := link.TradeEvolution(); !ok || next != "alakazam" {
+		t.Errorf("Expected alakazam when traded, got %q", next)
+	}
+
+	link.EvolvesTo[0].EvolutionDetails[0].HeldItem = &NamedAPIResource{Name: "metal-coat"}
+	if _, ok := link.TradeEvolution(); ok {
+		t.Error("Expected no trade evolution without the held item")
+	}
+}
@@ -0,0 +1,60 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+const baseURL = "https://pokeapi.co/api/v2"
+
+// ErrNotFound is returned (wrapped) when PokeAPI has no resource by the
+// requested name, typically because of a typo.
+var ErrNotFound = errors.New("resource not found")
+
+// fetchJSON retrieves url, consulting the cache first, and decodes the JSON
+// response into v. Successful responses are stored in the cache.
+func fetchJSON(cache *pokecache.Cache, url string, v any) error {
+	body, err := fetchBytes(cache, url)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse response from %s: %v", url, err)
+	}
+	return nil
+}
+
+// fetchBytes returns the raw response body for url, consulting the cache first.
+func fetchBytes(cache *pokecache.Cache, url string) ([]byte, error) {
+	if cachedData, found := cache.Get(url); found {
+		logger.Printf("cache hit %s", url)
+		return cachedData, nil
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	logger.Printf("GET %s: %s", url, resp.Status)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	cache.Add(url, body)
+	return body, nil
+}
@@ -0,0 +1,62 @@
+package pokeapi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// MaxLevel is the highest level a Pokémon can reach.
+const MaxLevel = 100
+
+type GrowthRateLevel struct {
+	Level      int `json:"level"`
+	Experience int `json:"experience"` // Total experience needed to reach the level
+}
+
+// GrowthRate is an experience curve shared by many species, such as
+// medium-slow.
+type GrowthRate struct {
+	ID     int               `json:"id"`
+	Name   string            `json:"name"`
+	Levels []GrowthRateLevel `json:"levels"`
+}
+
+func GetGrowthRate(cache *pokecache.Cache, name string) (*GrowthRate, error) {
+	if name == "" {
+		return nil, fmt.Errorf("growth rate name cannot be empty when fetching growth rate info")
+	}
+
+	var rate GrowthRate
+	if err := fetchJSON(cache, fmt.Sprintf("%s/growth-rate/%s", baseURL, name), &rate); err != nil {
+		return nil, err
+	}
+	sort.Slice(rate.Levels, func(i, j int) bool { return rate.Levels[i].Level < rate.Levels[j].Level })
+	return &rate, nil
+}
+
+// ExperienceAt returns the total experience needed to reach level.
+func (g *GrowthRate) ExperienceAt(level int) int {
+	experience := 0
+	for _, l := range g.Levels {
+		if l.Level > level {
+			break
+		}
+		experience = l.Experience
+	}
+	return experience
+}
+
+// LevelFor returns the level a Pokémon with the given total experience has
+// reached.
+func (g *GrowthRate) LevelFor(experience int) int {
+	level := 1
+	for _, l := range g.Levels {
+		if l.Experience > experience {
+			break
+		}
+		level = l.Level
+	}
+	return level
+}
@@ -0,0 +1,38 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetGrowthRate_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/growth-rate/medium", []byte(`{
+		"id": 2,
+		"name": "medium",
+		"levels": [
+			{"level": 3, "experience": 27},
+			{"level": 1, "experience": 0},
+			{"level": 2, "experience": 8}
+		]
+	}`))
+
+	rate, err := GetGrowthRate(cache, "medium")
+	if err != nil {
+		t.Fatalf("GetGrowthRate returned an error: %v", err)
+	}
+	if got := rate.ExperienceAt(2); got != 8 {
+		t.Errorf("Expected 8 experience at level 2, got %d", got)
+	}
+	for experience, want := range map[int]int{0: 1, 7: 1, 8: 2, 26: 2, 1000: 3} {
+		if got := rate.LevelFor(experience); got != want {
+			t.Errorf("LevelFor(%d) = %d, want %d", experience, got, want)
+		}
+	}
+
+	if _, err := GetGrowthRate(cache, ""); err == nil {
+		t.Error("Expected an error for an empty growth rate name")
+	}
+}
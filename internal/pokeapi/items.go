@@ -0,0 +1,47 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+type Item struct {
+	ID            int                `json:"id"`
+	Name          string             `json:"name"`
+	Cost          int                `json:"cost"`
+	FlingPower    *int               `json:"fling_power"`
+	Category      NamedAPIResource   `json:"category"`
+	Attributes    []NamedAPIResource `json:"attributes"`
+	EffectEntries []VerboseEffect    `json:"effect_entries"`
+	Names         []Name             `json:"names"`
+}
+
+// ShortEffect returns the English short effect text of the item, or an empty
+// string when none is available.
+func (i *Item) ShortEffect() string {
+	for _, entry := range i.EffectEntries {
+		if entry.Language.Name == "en" {
+			return entry.ShortEffect
+		}
+	}
+	return ""
+}
+
+func GetItem(cache *pokecache.Cache, itemName string) (*Item, error) {
+	if itemName == "" {
+		return nil, fmt.Errorf("item name cannot be empty when fetching item info")
+	}
+
+	var item Item
+	if err := fetchJSON(cache, fmt.Sprintf("%s/item/%s", baseURL, itemName), &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
@@ -0,0 +1,41 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetItem_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/item/great-ball", []byte(`{
+		"id": 3,
+		"name": "great-ball",
+		"cost": 600,
+		"category": {"name": "standard-balls", "url": ""},
+		"effect_entries": [
+			{"effect": "Used to catch Pokémon.", "short_effect": "Tries to catch a wild Pokémon, success rate 1.5x.", "language": {"name": "en", "url": ""}}
+		]
+	}`))
+
+	item, err := GetItem(cache, "great-ball")
+	if err != nil {
+		t.Fatalf("GetItem returned an error: %v", err)
+	}
+
+	if item.Cost != 600 || item.Category.Name != "standard-balls" {
+		t.Errorf("Unexpected item decoded: %+v", item)
+	}
+	if item.ShortEffect() == "" {
+		t.Error("Expected an English short effect")
+	}
+}
+
+func TestGetItem_EmptyName(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+
+	if _, err := GetItem(cache, ""); err == nil {
+		t.Error("Expected error for empty item name")
+	}
+}
@@ -0,0 +1,49 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+type Region struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Locations      []NamedAPIResource `json:"locations"`
+	MainGeneration NamedAPIResource   `json:"main_generation"`
+	Pokedexes      []NamedAPIResource `json:"pokedexes"`
+	VersionGroups  []NamedAPIResource `json:"version_groups"`
+	Names          []Name             `json:"names"`
+}
+
+type Location struct {
+	ID     int                `json:"id"`
+	Name   string             `json:"name"`
+	Region *NamedAPIResource  `json:"region"`
+	Areas  []NamedAPIResource `json:"areas"`
+	Names  []Name             `json:"names"`
+}
+
+func GetRegion(cache *pokecache.Cache, regionName string) (*Region, error) {
+	if regionName == "" {
+		return nil, fmt.Errorf("region name cannot be empty when fetching region info")
+	}
+
+	var region Region
+	if err := fetchJSON(cache, fmt.Sprintf("%s/region/%s", baseURL, regionName), &region); err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+func GetLocation(cache *pokecache.Cache, locationName string) (*Location, error) {
+	if locationName == "" {
+		return nil, fmt.Errorf("location name cannot be empty when fetching location info")
+	}
+
+	var location Location
+	if err := fetchJSON(cache, fmt.Sprintf("%s/location/%s", baseURL, locationName), &location); err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
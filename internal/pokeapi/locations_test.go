@@ -0,0 +1,59 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetRegion_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/region/kanto", []byte(`{
+		"id": 1,
+		"name": "kanto",
+		"locations": [{"name": "pallet-town", "url": ""}, {"name": "kanto-route-1", "url": ""}],
+		"main_generation": {"name": "generation-i", "url": ""},
+		"pokedexes": [{"name": "kanto", "url": ""}]
+	}`))
+
+	region, err := GetRegion(cache, "kanto")
+	if err != nil {
+		t.Fatalf("GetRegion returned an error: %v", err)
+	}
+	if len(region.Locations) != 2 || region.MainGeneration.Name != "generation-i" {
+		t.Errorf("Unexpected region decoded: %+v", region)
+	}
+}
+
+func TestGetLocation_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/location/kanto-route-1", []byte(`{
+		"id": 88,
+		"name": "kanto-route-1",
+		"region": {"name": "kanto", "url": ""},
+		"areas": [{"name": "kanto-route-1-area", "url": ""}]
+	}`))
+
+	location, err := GetLocation(cache, "kanto-route-1")
+	if err != nil {
+		t.Fatalf("GetLocation returned an error: %v", err)
+	}
+	if location.Region == nil || location.Region.Name != "kanto" {
+		t.Errorf("Expected location to belong to kanto, got %+v", location.Region)
+	}
+	if len(location.Areas) != 1 || location.Areas[0].Name != "kanto-route-1-area" {
+		t.Errorf("Unexpected areas decoded: %+v", location.Areas)
+	}
+}
+
+func TestGetRegion_EmptyName(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+
+	if _, err := GetRegion(cache, ""); err == nil {
+		t.Error("Expected error for empty region name")
+	}
+	if _, err := GetLocation(cache, ""); err == nil {
+		t.Error("Expected error for empty location name")
+	}
+}
@@ -0,0 +1,18 @@
+package pokeapi
+
+import (
+	"io"
+	"log"
+)
+
+// logger receives diagnostic messages about API requests. It discards them
+// unless SetLogger is called.
+var logger = log.New(io.Discard, "", 0)
+
+// SetLogger directs diagnostic messages to l. Passing nil silences them.
+func SetLogger(l *log.Logger) {
+	if l == nil {
+		l = log.New(io.Discard, "", 0)
+	}
+	logger = l
+}
@@ -0,0 +1,27 @@
+package pokeapi
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestSetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(log.New(&buf, "", 0))
+	defer SetLogger(nil)
+
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/item/potion", []byte(`{"id": 17, "name": "potion"}`))
+	if _, err := GetItem(cache, "potion"); err != nil {
+		t.Fatalf("GetItem returned an error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "cache hit https://pokeapi.co/api/v2/item/potion") {
+		t.Errorf("Expected the cache hit to be logged, got %q", buf.String())
+	}
+}
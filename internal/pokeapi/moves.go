@@ -0,0 +1,90 @@
+package pokeapi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// Move describes a move. Power and Accuracy are nil for moves that deal no
+// direct damage or never miss.
+type Move struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Power       *int             `json:"power"`
+	Accuracy    *int             `json:"accuracy"`
+	PP          int              `json:"pp"`
+	Priority    int              `json:"priority"`
+	Type        NamedAPIResource `json:"type"`
+	DamageClass NamedAPIResource `json:"damage_class"` // physical, special or status
+}
+
+// PokemonMove is a move a Pokémon can learn and how it learns it in each
+// version group.
+type PokemonMove struct {
+	Move                NamedAPIResource     `json:"move"`
+	VersionGroupDetails []MoveLearnedDetails `json:"version_group_details"`
+}
+
+type MoveLearnedDetails struct {
+	LevelLearnedAt  int              `json:"level_learned_at"`
+	MoveLearnMethod NamedAPIResource `json:"move_learn_method"`
+	VersionGroup    NamedAPIResource `json:"version_group"`
+}
+
+func GetMove(cache *pokecache.Cache, moveName string) (*Move, error) {
+	if moveName == "" {
+		return nil, fmt.Errorf("move name cannot be empty when fetching move info")
+	}
+
+	var move Move
+	if err := fetchJSON(cache, fmt.Sprintf("%s/move/%s", baseURL, moveName), &move); err != nil {
+		return nil, err
+	}
+	return &move, nil
+}
+
+// GetPokemonMoves returns the moves a Pokémon can learn. They come from the
+// same response as GetPokemonInfo but are kept out of PokemonInfo, which is
+// stored in save files, because the list is long.
+func GetPokemonMoves(cache *pokecache.Cache, pokemonName string) ([]PokemonMove, error) {
+	if pokemonName == "" {
+		return nil, fmt.Errorf("pokemon name cannot be empty when fetching moves")
+	}
+
+	var pokemon struct {
+		Moves []PokemonMove `json:"moves"`
+	}
+	if err := fetchJSON(cache, fmt.Sprintf("%s/pokemon/%s", baseURL, pokemonName), &pokemon); err != nil {
+		return nil, err
+	}
+	return pokemon.Moves, nil
+}
+
+// LevelUpMoves returns the moves learned by leveling up at or below level,
+// most recently learned first. A move counts from the lowest level any
+// version group teaches it.
+func LevelUpMoves(moves []PokemonMove, level int) []string {
+	learnedAt := make(map[string]int)
+	for _, move := range moves {
+		for _, details := range move.VersionGroupDetails {
+			if details.MoveLearnMethod.Name != "level-up" || details.LevelLearnedAt > level {
+				continue
+			}
+			if current, seen := learnedAt[move.Move.Name]; !seen || details.LevelLearnedAt < current {
+				learnedAt[move.Move.Name] = details.LevelLearnedAt
+			}
+		}
+	}
+
+	names := make([]string, 0, len(learnedAt))
+	for name := range learnedAt {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(learnedAt[b], learnedAt[a]), cmp.Compare(a, b))
+	})
+	return names
+}
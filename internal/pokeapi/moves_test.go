@@ -0,0 +1,61 @@
+package pokeapi
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetMove_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/move/growl", []byte(`{
+		"id": 45,
+		"name": "growl",
+		"power": null,
+		"accuracy": 100,
+		"pp": 40,
+		"type": {"name": "normal", "url": ""},
+		"damage_class": {"name": "status", "url": ""}
+	}`))
+
+	move, err := GetMove(cache, "growl")
+	if err != nil {
+		t.Fatalf("GetMove returned an error: %v", err)
+	}
+	if move.Power != nil || move.Accuracy == nil || *move.Accuracy != 100 || move.DamageClass.Name != "status" {
+		t.Errorf("Unexpected move decoded: %+v", move)
+	}
+}
+
+func TestLevelUpMoves(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/pokemon/pikachu", []byte(`{
+		"name": "pikachu",
+		"moves": [
+			{"move": {"name": "thunder-shock"}, "version_group_details": [
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}
+			]},
+			{"move": {"name": "quick-attack"}, "version_group_details": [
+				{"level_learned_at": 16, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}},
+				{"level_learned_at": 6, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "x-y"}}
+			]},
+			{"move": {"name": "thunder"}, "version_group_details": [
+				{"level_learned_at": 43, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}
+			]},
+			{"move": {"name": "thunderbolt"}, "version_group_details": [
+				{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}}
+			]}
+		]
+	}`))
+
+	moves, err := GetPokemonMoves(cache, "pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemonMoves returned an error: %v", err)
+	}
+	got := LevelUpMoves(moves, 10)
+	if want := []string{"quick-attack", "thunder-shock"}; !slices.Equal(got, want) {
+		t.Errorf("LevelUpMoves(10) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// Nature describes a nature. IncreasedStat and DecreasedStat are nil for
+// neutral natures, as are the flavor preferences.
+type Nature struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	DecreasedStat *NamedAPIResource `json:"decreased_stat"`
+	IncreasedStat *NamedAPIResource `json:"increased_stat"`
+	HatesFlavor   *NamedAPIResource `json:"hates_flavor"`
+	LikesFlavor   *NamedAPIResource `json:"likes_flavor"`
+	Names         []Name            `json:"names"`
+}
+
+type Description struct {
+	Description string           `json:"description"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+type Characteristic struct {
+	ID             int              `json:"id"`
+	GeneModulo     int              `json:"gene_modulo"`
+	PossibleValues []int            `json:"possible_values"`
+	HighestStat    NamedAPIResource `json:"highest_stat"`
+	Descriptions   []Description    `json:"descriptions"`
+}
+
+// Description returns the English description of the characteristic.
+func (c *Characteristic) Description() string {
+	for _, description := range c.Descriptions {
+		if description.Language.Name == "en" {
+			return description.Description
+		}
+	}
+	return ""
+}
+
+func GetNature(cache *pokecache.Cache, natureName string) (*Nature, error) {
+	if natureName == "" {
+		return nil, fmt.Errorf("nature name cannot be empty when fetching nature info")
+	}
+
+	var nature Nature
+	if err := fetchJSON(cache, fmt.Sprintf("%s/nature/%s", baseURL, natureName), &nature); err != nil {
+		return nil, err
+	}
+	return &nature, nil
+}
+
+func GetCharacteristic(cache *pokecache.Cache, id int) (*Characteristic, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("characteristic id must be positive, got %d", id)
+	}
+
+	var characteristic Characteristic
+	if err := fetchJSON(cache, fmt.Sprintf("%s/characteristic/%d", baseURL, id), &characteristic); err != nil {
+		return nil, err
+	}
+	return &characteristic, nil
+}
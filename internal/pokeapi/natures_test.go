@@ -0,0 +1,63 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetNature_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/nature/adamant", []byte(`{
+		"id": 3,
+		"name": "adamant",
+		"increased_stat": {"name": "attack", "url": ""},
+		"decreased_stat": {"name": "special-attack", "url": ""}
+	}`))
+	cache.Add("https://pokeapi.co/api/v2/nature/hardy", []byte(`{
+		"id": 1,
+		"name": "hardy",
+		"increased_stat": null,
+		"decreased_stat": null
+	}`))
+
+	adamant, err := GetNature(cache, "adamant")
+	if err != nil {
+		t.Fatalf("GetNature returned an error: %v", err)
+	}
+	if adamant.IncreasedStat == nil || adamant.IncreasedStat.Name != "attack" {
+		t.Errorf("Expected adamant to boost attack, got %+v", adamant.IncreasedStat)
+	}
+
+	hardy, err := GetNature(cache, "hardy")
+	if err != nil {
+		t.Fatalf("GetNature returned an error: %v", err)
+	}
+	if hardy.IncreasedStat != nil || hardy.DecreasedStat != nil {
+		t.Error("Expected hardy to be a neutral nature")
+	}
+}
+
+func TestGetCharacteristic_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/characteristic/1", []byte(`{
+		"id": 1,
+		"gene_modulo": 0,
+		"possible_values": [0, 5, 10, 15, 20, 25, 30],
+		"highest_stat": {"name": "hp", "url": ""},
+		"descriptions": [{"description": "Loves to eat", "language": {"name": "en", "url": ""}}]
+	}`))
+
+	characteristic, err := GetCharacteristic(cache, 1)
+	if err != nil {
+		t.Fatalf("GetCharacteristic returned an error: %v", err)
+	}
+	if characteristic.Description() != "Loves to eat" || characteristic.HighestStat.Name != "hp" {
+		t.Errorf("Unexpected characteristic decoded: %+v", characteristic)
+	}
+
+	if _, err := GetCharacteristic(cache, 0); err == nil {
+		t.Error("Expected error for non-positive characteristic id")
+	}
+}
@@ -0,0 +1,96 @@
+package pokeapi
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// NamedAPIResourceList is a page of a PokeAPI list endpoint.
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+// DefaultPageSize matches the page size PokeAPI uses when none is requested.
+const DefaultPageSize = 20
+
+// List endpoints that return NamedAPIResource pages.
+const (
+	EndpointPokemon       = "pokemon"
+	EndpointItems         = "item"
+	EndpointMoves         = "move"
+	EndpointTypes         = "type"
+	EndpointBerries       = "berry"
+	EndpointNatures       = "nature"
+	EndpointRegions       = "region"
+	EndpointLocations     = "location"
+	EndpointLocationAreas = "location-area"
+	EndpointPokedexes     = "pokedex"
+)
+
+// GetResourceList fetches a single page of a list endpoint.
+func GetResourceList(cache *pokecache.Cache, endpoint string, offset, limit int) (*NamedAPIResourceList, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf("endpoint cannot be empty when fetching a resource list")
+	}
+	if offset < 0 || limit <= 0 {
+		return nil, fmt.Errorf("invalid page (offset %d, limit %d)", offset, limit)
+	}
+
+	var list NamedAPIResourceList
+	if err := fetchJSON(cache, resourceListURL(endpoint, offset, limit), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func resourceListURL(endpoint string, offset, limit int) string {
+	return fmt.Sprintf("%s/%s?offset=%d&limit=%d", baseURL, endpoint, offset, limit)
+}
+
+// Pages lazily walks a list endpoint page by page, following the next links
+// returned by the API. Iteration stops after the first error.
+func Pages(cache *pokecache.Cache, endpoint string, pageSize int) iter.Seq2[*NamedAPIResourceList, error] {
+	return func(yield func(*NamedAPIResourceList, error) bool) {
+		if pageSize <= 0 {
+			pageSize = DefaultPageSize
+		}
+
+		page, err := GetResourceList(cache, endpoint, 0, pageSize)
+		for {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) || page.Next == nil {
+				return
+			}
+
+			var next NamedAPIResourceList
+			err = fetchJSON(cache, *page.Next, &next)
+			page = &next
+		}
+	}
+}
+
+// Resources lazily yields every resource of a list endpoint, fetching pages
+// of pageSize only as they are needed.
+func Resources(cache *pokecache.Cache, endpoint string, pageSize int) iter.Seq2[NamedAPIResource, error] {
+	return func(yield func(NamedAPIResource, error) bool) {
+		for page, err := range Pages(cache, endpoint, pageSize) {
+			if err != nil {
+				yield(NamedAPIResource{}, err)
+				return
+			}
+			for _, resource := range page.Results {
+				if !yield(resource, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func seedTypePages(cache *pokecache.Cache) {
+	cache.Add("https://pokeapi.co/api/v2/type?offset=0&limit=2", []byte(`{
+		"count": 3,
+		"next": "https://pokeapi.co/api/v2/type?offset=2&limit=2",
+		"previous": null,
+		"results": [{"name": "normal", "url": ""}, {"name": "fighting", "url": ""}]
+	}`))
+	cache.Add("https://pokeapi.co/api/v2/type?offset=2&limit=2", []byte(`{
+		"count": 3,
+		"next": null,
+		"previous": "https://pokeapi.co/api/v2/type?offset=0&limit=2",
+		"results": [{"name": "flying", "url": ""}]
+	}`))
+}
+
+func TestResources_FollowsPages(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	seedTypePages(cache)
+
+	var names []string
+	for resource, err := range Resources(cache, EndpointTypes, 2) {
+		if err != nil {
+			t.Fatalf("Resources yielded an error: %v", err)
+		}
+		names = append(names, resource.Name)
+	}
+
+	expected := []string{"normal", "fighting", "flying"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, names)
+			break
+		}
+	}
+}
+
+func TestResources_StopsEarly(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	// Only the first page is available; stopping early must not fetch the second.
+	cache.Add("https://pokeapi.co/api/v2/type?offset=0&limit=2", []byte(`{
+		"count": 3,
+		"next": "http://127.0.0.1:0/unreachable",
+		"results": [{"name": "normal", "url": ""}, {"name": "fighting", "url": ""}]
+	}`))
+
+	count := 0
+	for _, err := range Resources(cache, EndpointTypes, 2) {
+		if err != nil {
+			t.Fatalf("Resources yielded an error: %v", err)
+		}
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Expected to read 2 resources, got %d", count)
+	}
+}
+
+func TestPages_Error(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/type?offset=0&limit=2", []byte(`{
+		"count": 3,
+		"next": "http://127.0.0.1:0/unreachable",
+		"results": [{"name": "normal", "url": ""}, {"name": "fighting", "url": ""}]
+	}`))
+
+	pages, errs := 0, 0
+	for page, err := range Pages(cache, EndpointTypes, 2) {
+		if err != nil {
+			errs++
+			continue
+		}
+		if page != nil {
+			pages++
+		}
+	}
+	if pages != 1 || errs != 1 {
+		t.Errorf("Expected 1 page followed by 1 error, got %d pages and %d errors", pages, errs)
+	}
+}
+
+func TestGetResourceList_InvalidPage(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+
+	if _, err := GetResourceList(cache, EndpointTypes, -1, 20); err == nil {
+		t.Error("Expected error for negative offset")
+	}
+	if _, err := GetResourceList(cache, EndpointTypes, 0, 0); err == nil {
+		t.Error("Expected error for zero limit")
+	}
+	if _, err := GetResourceList(cache, "", 0, 20); err == nil {
+		t.Error("Expected error for empty endpoint")
+	}
+}
+
+func TestPages_DefaultPageSize(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/location-area?offset=0&limit=20", []byte(`{
+		"count": 21,
+		"next": "https://pokeapi.co/api/v2/location-area?offset=20&limit=20",
+		"previous": null,
+		"results": [{"name": "canalave-city-area", "url": ""}]
+	}`))
+	cache.Add("https://pokeapi.co/api/v2/location-area?offset=20&limit=20", []byte(`{
+		"count": 21,
+		"next": null,
+		"previous": "https://pokeapi.co/api/v2/location-area?offset=0&limit=20",
+		"results": [{"name": "eterna-city-area", "url": ""}]
+	}`))
+
+	var pages []*NamedAPIResourceList
+	for page, err := range Pages(cache, EndpointLocationAreas, 0) {
+		if err != nil {
+			t.Fatalf("Pages yielded an error: %v", err)
+		}
+		pages = append(pages, page)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("Expected 2 pages, got %d", len(pages))
+	}
+	if pages[0].Previous != nil || pages[0].Next == nil {
+		t.Errorf("Expected the first page to link only forwards, got %+v", pages[0])
+	}
+	if pages[1].Previous == nil || pages[1].Next != nil {
+		t.Errorf("Expected the last page to link only backwards, got %+v", pages[1])
+	}
+}
+
+func TestPages_InvalidEndpoint(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/invalid-endpoint?offset=0&limit=20", []byte(`not json`))
+
+	for _, err := range Pages(cache, "invalid-endpoint", 0) {
+		if err == nil {
+			t.Fatal("Expected an error for an invalid endpoint")
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package pokeapi
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// PokedexEntry is a species listed in a Pokédex under its regional number.
+type PokedexEntry struct {
+	EntryNumber    int              `json:"entry_number"`
+	PokemonSpecies NamedAPIResource `json:"pokemon_species"`
+}
+
+// Pokedex is a regional Pokédex such as kanto, or the national one.
+type Pokedex struct {
+	ID             int               `json:"id"`
+	Name           string            `json:"name"`
+	IsMainSeries   bool              `json:"is_main_series"`
+	Region         *NamedAPIResource `json:"region"` // Nil for the national Pokédex
+	PokemonEntries []PokedexEntry    `json:"pokemon_entries"`
+}
+
+func GetPokedex(cache *pokecache.Cache, pokedexName string) (*Pokedex, error) {
+	if pokedexName == "" {
+		return nil, fmt.Errorf("pokedex name cannot be empty when fetching pokedex info")
+	}
+
+	var pokedex Pokedex
+	if err := fetchJSON(cache, fmt.Sprintf("%s/pokedex/%s", baseURL, pokedexName), &pokedex); err != nil {
+		return nil, err
+	}
+	return &pokedex, nil
+}
+
+// ResourceID returns the numeric ID at the end of a resource URL such as
+// https://pokeapi.co/api/v2/pokemon-species/25/, or 0 if there is none. For
+// species and default Pokémon this is the national Pokédex number.
+func ResourceID(url string) int {
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(url, "/")))
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,48 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetPokedex_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/pokedex/kanto", []byte(`{
+		"id": 2,
+		"name": "kanto",
+		"is_main_series": true,
+		"region": {"name": "kanto", "url": "https://pokeapi.co/api/v2/region/1/"},
+		"pokemon_entries": [
+			{"entry_number": 1, "pokemon_species": {"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon-species/1/"}},
+			{"entry_number": 25, "pokemon_species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"}}
+		]
+	}`))
+
+	pokedex, err := GetPokedex(cache, "kanto")
+	if err != nil {
+		t.Fatalf("GetPokedex returned an error: %v", err)
+	}
+	if len(pokedex.PokemonEntries) != 2 || pokedex.Region == nil || pokedex.Region.Name != "kanto" {
+		t.Errorf("Unexpected pokedex decoded: %+v", pokedex)
+	}
+
+	if _, err := GetPokedex(cache, ""); err == nil {
+		t.Error("Expected an error for an empty pokedex name")
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	cases := map[string]int{
+		"https://pokeapi.co/api/v2/pokemon-species/25/": 25,
+		"https://pokeapi.co/api/v2/pokemon/10034":       10034,
+		"https://pokeapi.co/api/v2/pokemon/pikachu/":    0,
+		"": 0,
+	}
+	for url, want := range cases {
+		if got := ResourceID(url); got != want {
+			t.Errorf("ResourceID(%q) = %d, want %d", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// PokemonSpecies holds the data shared by every form of a Pokémon, such as
+// how easy it is to catch.
+type PokemonSpecies struct {
+	ID             int              `json:"id"`
+	Name           string           `json:"name"`
+	CaptureRate    int              `json:"capture_rate"` // 3 (legendaries) to 255 (easiest)
+	IsLegendary    bool             `json:"is_legendary"`
+	IsMythical     bool             `json:"is_mythical"`
+	GenderRate     int              `json:"gender_rate"` // Chance of being female in eighths, or -1 for genderless
+	GrowthRate     NamedAPIResource `json:"growth_rate"` // How much experience each level takes
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+}
+
+func GetPokemonSpecies(cache *pokecache.Cache, speciesName string) (*PokemonSpecies, error) {
+	if speciesName == "" {
+		return nil, fmt.Errorf("species name cannot be empty when fetching species info")
+	}
+
+	var species PokemonSpecies
+	if err := fetchJSON(cache, fmt.Sprintf("%s/pokemon-species/%s", baseURL, speciesName), &species); err != nil {
+		return nil, err
+	}
+	return &species, nil
+}
+
+// SpeciesName returns the name of the species a Pokémon belongs to. Forms
+// such as deoxys-attack share their species with the base Pokémon.
+func (p *PokemonInfo) SpeciesName() string {
+	if p.Species.Name != "" {
+		return p.Species.Name
+	}
+	return p.Name
+}
+
+// SpeciesID returns the National Pokédex number of the species a Pokémon
+// belongs to. Forms have IDs of their own, so this can differ from ID.
+func (p *PokemonInfo) SpeciesID() int {
+	if id := ResourceID(p.Species.URL); id != 0 {
+		return id
+	}
+	return p.ID
+}
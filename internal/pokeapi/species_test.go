@@ -0,0 +1,42 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetPokemonSpecies_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/pokemon-species/mewtwo", []byte(`{
+		"id": 150,
+		"name": "mewtwo",
+		"capture_rate": 3,
+		"is_legendary": true,
+		"is_mythical": false
+	}`))
+
+	species, err := GetPokemonSpecies(cache, "mewtwo")
+	if err != nil {
+		t.Fatalf("GetPokemonSpecies returned an error: %v", err)
+	}
+	if species.CaptureRate != 3 || !species.IsLegendary {
+		t.Errorf("Unexpected species decoded: %+v", species)
+	}
+
+	if _, err := GetPokemonSpecies(cache, ""); err == nil {
+		t.Error("Expected an error for an empty species name")
+	}
+}
+
+func TestPokemonInfo_SpeciesName(t *testing.T) {
+	form := PokemonInfo{Name: "deoxys-attack", Species: NamedAPIResource{Name: "deoxys"}}
+	if got := form.SpeciesName(); got != "deoxys" {
+		t.Errorf("Expected deoxys, got %q", got)
+	}
+	bare := PokemonInfo{Name: "pikachu"}
+	if got := bare.SpeciesName(); got != "pikachu" {
+		t.Errorf("Expected pikachu, got %q", got)
+	}
+}
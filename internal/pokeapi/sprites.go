@@ -0,0 +1,72 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// PokemonSprites holds the URLs of a Pokémon's sprite PNGs. A variant the
+// Pokémon has no sprite for is an empty string.
+type PokemonSprites struct {
+	FrontDefault     string `json:"front_default"`
+	FrontShiny       string `json:"front_shiny"`
+	FrontFemale      string `json:"front_female"`
+	FrontShinyFemale string `json:"front_shiny_female"`
+	BackDefault      string `json:"back_default"`
+	BackShiny        string `json:"back_shiny"`
+	BackFemale       string `json:"back_female"`
+	BackShinyFemale  string `json:"back_shiny_female"`
+}
+
+// SpriteVariant selects one of the sprites in PokemonSprites.
+type SpriteVariant struct {
+	Back   bool
+	Shiny  bool
+	Female bool
+}
+
+func (v SpriteVariant) String() string {
+	name := "front"
+	if v.Back {
+		name = "back"
+	}
+	if v.Shiny {
+		name += " shiny"
+	}
+	if v.Female {
+		name += " female"
+	}
+	return name
+}
+
+// URL returns the sprite URL for the variant, or "" when there is none.
+func (s PokemonSprites) URL(v SpriteVariant) string {
+	switch v {
+	case SpriteVariant{}:
+		return s.FrontDefault
+	case SpriteVariant{Shiny: true}:
+		return s.FrontShiny
+	case SpriteVariant{Female: true}:
+		return s.FrontFemale
+	case SpriteVariant{Shiny: true, Female: true}:
+		return s.FrontShinyFemale
+	case SpriteVariant{Back: true}:
+		return s.BackDefault
+	case SpriteVariant{Back: true, Shiny: true}:
+		return s.BackShiny
+	case SpriteVariant{Back: true, Female: true}:
+		return s.BackFemale
+	default:
+		return s.BackShinyFemale
+	}
+}
+
+// GetSprite returns the PNG data at a sprite URL, caching it like any other
+// response.
+func GetSprite(cache *pokecache.Cache, url string) ([]byte, error) {
+	if url == "" {
+		return nil, fmt.Errorf("sprite URL cannot be empty")
+	}
+	return fetchBytes(cache, url)
+}
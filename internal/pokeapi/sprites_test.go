@@ -0,0 +1,58 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestPokemonSprites_Decoded(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/pokemon/pikachu", []byte(`{
+		"id": 25,
+		"name": "pikachu",
+		"sprites": {
+			"front_default": "https://example.com/front.png",
+			"front_shiny": "https://example.com/shiny.png",
+			"front_female": null,
+			"back_shiny_female": "https://example.com/back-shiny-female.png"
+		}
+	}`))
+
+	pokemon, err := GetPokemonInfo(cache, "pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemonInfo returned an error: %v", err)
+	}
+
+	cases := []struct {
+		variant SpriteVariant
+		want    string
+	}{
+		{SpriteVariant{}, "https://example.com/front.png"},
+		{SpriteVariant{Shiny: true}, "https://example.com/shiny.png"},
+		{SpriteVariant{Female: true}, ""},
+		{SpriteVariant{Back: true, Shiny: true, Female: true}, "https://example.com/back-shiny-female.png"},
+	}
+	for _, c := range cases {
+		if got := pokemon.Sprites.URL(c.variant); got != c.want {
+			t.Errorf("URL(%s) = %q, want %q", c.variant, got, c.want)
+		}
+	}
+}
+
+func TestGetSprite_Cached(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://example.com/front.png", []byte("png"))
+
+	data, err := GetSprite(cache, "https://example.com/front.png")
+	if err != nil {
+		t.Fatalf("GetSprite returned an error: %v", err)
+	}
+	if string(data) != "png" {
+		t.Errorf("Expected cached bytes, got %q", data)
+	}
+	if _, err := GetSprite(cache, ""); err == nil {
+		t.Error("Expected an error for an empty URL")
+	}
+}
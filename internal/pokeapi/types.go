@@ -0,0 +1,51 @@
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+// TypeRelations lists the types a type is strong or weak against.
+type TypeRelations struct {
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+}
+
+type Type struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	DamageRelations TypeRelations `json:"damage_relations"`
+}
+
+func GetType(cache *pokecache.Cache, typeName string) (*Type, error) {
+	if typeName == "" {
+		return nil, fmt.Errorf("type name cannot be empty when fetching type info")
+	}
+
+	var pokemonType Type
+	if err := fetchJSON(cache, fmt.Sprintf("%s/type/%s", baseURL, typeName), &pokemonType); err != nil {
+		return nil, err
+	}
+	return &pokemonType, nil
+}
+
+// Multipliers returns the damage multiplier this type's moves deal to each
+// defending type it is not neutral against.
+func (t *Type) Multipliers() map[string]float64 {
+	multipliers := make(map[string]float64)
+	for _, target := range t.DamageRelations.DoubleDamageTo {
+		multipliers[target.Name] = 2
+	}
+	for _, target := range t.DamageRelations.HalfDamageTo {
+		multipliers[target.Name] = 0.5
+	}
+	for _, target := range t.DamageRelations.NoDamageTo {
+		multipliers[target.Name] = 0
+	}
+	return multipliers
+}
@@ -0,0 +1,36 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokecache"
+)
+
+func TestGetType_Multipliers(t *testing.T) {
+	cache := pokecache.NewCache(time.Second * 5)
+	cache.Add("https://pokeapi.co/api/v2/type/electric", []byte(`{
+		"id": 13,
+		"name": "electric",
+		"damage_relations": {
+			"double_damage_to": [{"name": "water"}, {"name": "flying"}],
+			"half_damage_to": [{"name": "grass"}, {"name": "electric"}],
+			"no_damage_to": [{"name": "ground"}]
+		}
+	}`))
+
+	electric, err := GetType(cache, "electric")
+	if err != nil {
+		t.Fatalf("GetType returned an error: %v", err)
+	}
+	multipliers := electric.Multipliers()
+	want := map[string]float64{"water": 2, "flying": 2, "grass": 0.5, "electric": 0.5, "ground": 0}
+	for defender, multiplier := range want {
+		if got, ok := multipliers[defender]; !ok || got != multiplier {
+			t.Errorf("Multiplier against %s = %v, want %v", defender, got, multiplier)
+		}
+	}
+	if _, ok := multipliers["normal"]; ok {
+		t.Error("Expected neutral matchups to be left out")
+	}
+}
@@ -0,0 +1,110 @@
+// Package render formats terminal output: aligned tables, colored type
+// badges and stat bars. Colors are only used when writing to a terminal and
+// NO_COLOR is not set, so piped output stays plain text.
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/term"
+)
+
+// MaxStat is the highest base stat a Pokémon can have; stat bars are scaled
+// against it.
+const MaxStat = 255
+
+const reset = "\x1b[0m"
+
+// typeColors are the conventional colors of each Pokémon type.
+var typeColors = map[string][3]uint8{
+	"normal":   {0xa8, 0xa7, 0x7a},
+	"fire":     {0xee, 0x81, 0x30},
+	"water":    {0x63, 0x90, 0xf0},
+	"electric": {0xf7, 0xd0, 0x2c},
+	"grass":    {0x7a, 0xc7, 0x4c},
+	"ice":      {0x96, 0xd9, 0xd6},
+	"fighting": {0xc2, 0x2e, 0x28},
+	"poison":   {0xa3, 0x3e, 0xa1},
+	"ground":   {0xe2, 0xbf, 0x65},
+	"flying":   {0xa9, 0x8f, 0xf3},
+	"psychic":  {0xf9, 0x55, 0x87},
+	"bug":      {0xa6, 0xb9, 0x1a},
+	"rock":     {0xb6, 0xa1, 0x36},
+	"ghost":    {0x73, 0x57, 0x97},
+	"dragon":   {0x6f, 0x35, 0xfc},
+	"dark":     {0x70, 0x57, 0x46},
+	"steel":    {0xb7, 0xb7, 0xce},
+	"fairy":    {0xd6, 0x85, 0xad},
+}
+
+// Style decides whether output is decorated with ANSI escape codes.
+type Style struct {
+	Color bool
+}
+
+// Detect returns the style suited to w: colored when w is a terminal, plain
+// when it is a file, a pipe or any other writer, or when NO_COLOR is set.
+func Detect(w io.Writer) Style {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return Style{}
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return Style{}
+	}
+	return Style{Color: term.IsTerminal(f.Fd())}
+}
+
+// Bold highlights text such as table headers.
+func (s Style) Bold(text string) string {
+	if !s.Color {
+		return text
+	}
+	return "\x1b[1m" + text + reset
+}
+
+// Type renders a type name as a badge in that type's color. Unknown types
+// are returned unchanged.
+func (s Style) Type(name string) string {
+	rgb, ok := typeColors[name]
+	if !s.Color || !ok {
+		return name
+	}
+	return fmt.Sprintf("\x1b[1;38;2;%d;%d;%dm%s%s", rgb[0], rgb[1], rgb[2], name, reset)
+}
+
+// Types renders a list of type names separated by slashes.
+func (s Style) Types(names []string) string {
+	badges := make([]string, len(names))
+	for i, name := range names {
+		badges[i] = s.Type(name)
+	}
+	return strings.Join(badges, "/")
+}
+
+// StatBar draws value as a bar of width cells scaled against MaxStat. Any
+// non-zero stat gets at least one filled cell. Plain bars use # and .; colored
+// bars go from red for weak stats to green for strong ones.
+func (s Style) StatBar(value, width int) string {
+	filled := min(width, (max(0, value)*width+MaxStat/2)/MaxStat)
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+
+	if !s.Color {
+		return strings.Repeat("#", filled) + strings.Repeat(".", width-filled)
+	}
+
+	color := "32" // green
+	switch {
+	case value < 50:
+		color = "31" // red
+	case value < 90:
+		color = "33" // yellow
+	}
+	return "\x1b[" + color + "m" + strings.Repeat("█", filled) + reset +
+		"\x1b[2m" + strings.Repeat("░", width-filled) + reset
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWidthIgnoresEscapes(t *testing.T) {
+	colored := Style{Color: true}
+	cases := map[string]int{
+		"fire":                      4,
+		colored.Type("fire"):        4,
+		colored.StatBar(100, 10):    10,
+		"Pokémon":                   7,
+		colored.Bold("NAME") + "  ": 6,
+	}
+	for s, want := range cases {
+		if got := Width(s); got != want {
+			t.Errorf("Width(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestStatBarScaling(t *testing.T) {
+	plain := Style{}
+	cases := []struct {
+		value int
+		want  string
+	}{
+		{0, ".........."},
+		{1, "#........."},
+		{128, "#####....."},
+		{255, "##########"},
+		{300, "##########"},
+	}
+	for _, c := range cases {
+		if got := plain.StatBar(c.value, 10); got != c.want {
+			t.Errorf("StatBar(%d) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
+
+func TestPlainStyleHasNoEscapes(t *testing.T) {
+	plain := Style{}
+	for _, s := range []string{plain.Type("grass"), plain.Bold("x"), plain.Types([]string{"grass", "poison"})} {
+		if strings.Contains(s, "\x1b") {
+			t.Errorf("Expected no escape codes, got %q", s)
+		}
+	}
+	if got := plain.Types([]string{"grass", "poison"}); got != "grass/poison" {
+		t.Errorf("Expected grass/poison, got %q", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	if Detect(&bytes.Buffer{}).Color {
+		t.Error("Expected no color for a buffer")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if Detect(nil).Color {
+		t.Error("Expected no color when NO_COLOR is set")
+	}
+}
+
+func TestTableAlignsColoredCells(t *testing.T) {
+	colored := Style{Color: true}
+	table := NewTable("NAME", "TYPES")
+	table.Indent = "  "
+	table.AddRow("bulbasaur", colored.Type("grass"))
+	table.AddRow("pikachu", colored.Type("electric"))
+
+	var out bytes.Buffer
+	if err := table.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", out.String())
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "  ") {
+			t.Errorf("Expected indented line, got %q", line)
+		}
+	}
+	if lines[0] != "  NAME       TYPES" {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+	if got := strings.Index(lines[2], "\x1b"); got != len("  pikachu    ") {
+		t.Errorf("Expected the type column to start at %d, got %d in %q", len("  pikachu    "), got, lines[2])
+	}
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// asciiRamp maps brightness to characters, darkest first, for terminals
+// without color.
+const asciiRamp = "@%#*+=-:."
+
+// Sprite draws img using one character per two pixel rows, cropped to its
+// opaque pixels. In color it uses upper and lower half blocks with truecolor
+// foreground and background; otherwise it falls back to ASCII shading.
+func (s Style) Sprite(img image.Image) string {
+	bounds := opaqueBounds(img)
+	var b strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		var line strings.Builder
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top := pixel(img, x, y)
+			bottom := color.NRGBA{}
+			if y+1 < bounds.Max.Y {
+				bottom = pixel(img, x, y+1)
+			}
+			if s.Color {
+				line.WriteString(halfBlock(top, bottom))
+			} else {
+				line.WriteByte(asciiShade(top, bottom))
+			}
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// pixel returns the color at x, y, or transparent when it is mostly see-through.
+func pixel(img image.Image, x, y int) color.NRGBA {
+	c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+	if c.A < 128 {
+		return color.NRGBA{}
+	}
+	return c
+}
+
+// opaqueBounds returns the smallest rectangle holding every visible pixel.
+func opaqueBounds(img image.Image) image.Rectangle {
+	var bounds image.Rectangle
+	full := img.Bounds()
+	for y := full.Min.Y; y < full.Max.Y; y++ {
+		for x := full.Min.X; x < full.Max.X; x++ {
+			if pixel(img, x, y).A != 0 {
+				bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return bounds
+}
+
+func halfBlock(top, bottom color.NRGBA) string {
+	switch {
+	case top.A == 0 && bottom.A == 0:
+		return " "
+	case bottom.A == 0:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm▀%s", top.R, top.G, top.B, reset)
+	case top.A == 0:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm▄%s", bottom.R, bottom.G, bottom.B, reset)
+	}
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀%s",
+		top.R, top.G, top.B, bottom.R, bottom.G, bottom.B, reset)
+}
+
+func asciiShade(top, bottom color.NRGBA) byte {
+	total, count := 0, 0
+	for _, c := range []color.NRGBA{top, bottom} {
+		if c.A != 0 {
+			// Rec. 601 luma, 0-255.
+			total += (299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000
+			count++
+		}
+	}
+	if count == 0 {
+		return ' '
+	}
+	return asciiRamp[total/count*len(asciiRamp)/256]
+}
@@ -0,0 +1,52 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// testSprite returns an 8x8 transparent image with a 2x3 block at (3, 2):
+// a white top row and black rows below it.
+func testSprite() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for x := 3; x < 5; x++ {
+		img.Set(x, 2, color.White)
+		img.Set(x, 3, color.Black)
+		img.Set(x, 4, color.Black)
+	}
+	return img
+}
+
+func TestSpritePlainCropsAndShades(t *testing.T) {
+	got := Style{}.Sprite(testSprite())
+	// Rows 2-3 average to mid grey; row 4 is black with nothing below.
+	want := "++\n@@\n"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSpriteColorUsesHalfBlocks(t *testing.T) {
+	got := Style{Color: true}.Sprite(testSprite())
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", got)
+	}
+	if !strings.Contains(lines[0], "\x1b[38;2;255;255;255m\x1b[48;2;0;0;0m▀") {
+		t.Errorf("Expected a white-over-black half block, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "\x1b[38;2;0;0;0m▀") || strings.Contains(lines[1], "48;2") {
+		t.Errorf("Expected a black upper half block without background, got %q", lines[1])
+	}
+	if Width(lines[0]) != 2 {
+		t.Errorf("Expected 2 columns, got %d", Width(lines[0]))
+	}
+}
+
+func TestSpriteEmptyImage(t *testing.T) {
+	if got := (Style{}).Sprite(image.NewNRGBA(image.Rect(0, 0, 4, 4))); got != "" {
+		t.Errorf("Expected no output for a transparent image, got %q", got)
+	}
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Table lays out rows in left-aligned columns. Unlike text/tabwriter it
+// ignores ANSI escape codes when measuring cells, so colored cells line up.
+type Table struct {
+	// Indent is written before every line.
+	Indent string
+	header []string
+	rows   [][]string
+}
+
+// NewTable returns a table with the given column headers. A table without
+// headers prints only its rows.
+func NewTable(header ...string) *Table {
+	return &Table{header: header}
+}
+
+func (t *Table) AddRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Write renders the table to w. Columns are separated by two spaces and the
+// last column is not padded.
+func (t *Table) Write(w io.Writer) error {
+	lines := t.rows
+	if len(t.header) > 0 {
+		lines = append([][]string{t.header}, t.rows...)
+	}
+
+	var widths []int
+	for _, line := range lines {
+		for i, cell := range line {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		var row strings.Builder
+		for i, cell := range line {
+			row.WriteString(cell)
+			if i < len(line)-1 {
+				row.WriteString(strings.Repeat(" ", widths[i]-Width(cell)+2))
+			}
+		}
+		// Empty trailing cells would otherwise leave padding at the end.
+		b.WriteString(t.Indent)
+		b.WriteString(strings.TrimRight(row.String(), " "))
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Width returns the number of terminal columns s occupies, skipping ANSI
+// escape sequences.
+func Width(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			// Skip to the final byte of the CSI sequence.
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		width++
+	}
+	return width
+}
@@ -0,0 +1,12 @@
+// Package term puts terminals into raw mode for interactive line editing.
+// Windows consoles are recognised but can't be made raw; other platforms
+// without termios support report that no terminal is attached.
+package term
+
+import (
+	"errors"
+)
+
+// ErrUnsupported is returned by MakeRaw on platforms without termios.
+// Callers should fall back to reading whole lines.
+var ErrUnsupported = errors.New("terminal raw mode is not supported on this platform")
@@ -0,0 +1,21 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || windows)
+
+package term
+
+// State is empty on platforms without termios.
+type State struct{}
+
+// IsTerminal always reports false on platforms without termios.
+func IsTerminal(fd uintptr) bool {
+	return false
+}
+
+// MakeRaw is not supported on platforms without termios.
+func MakeRaw(fd uintptr) (*State, error) {
+	return nil, ErrUnsupported
+}
+
+// Restore is a no-op on platforms without termios.
+func Restore(fd uintptr, state *State) error {
+	return nil
+}
@@ -0,0 +1,21 @@
+package term
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsTerminalFalseForFiles(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "term")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer f.Close()
+
+	if IsTerminal(f.Fd()) {
+		t.Error("A regular file should not be reported as a terminal")
+	}
+	if _, err := MakeRaw(f.Fd()); err == nil {
+		t.Error("Expected MakeRaw to fail on a regular file")
+	}
+}
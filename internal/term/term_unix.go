@@ -0,0 +1,62 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// State holds the terminal settings to restore after raw mode.
+type State struct {
+	termios syscall.Termios
+}
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	var termios syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlGetTermios, uintptr(unsafe.Pointer(&termios))); errno != 0 {
+		return nil, errno
+	}
+	return &termios, nil
+}
+
+func setTermios(fd uintptr, termios *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlSetTermios, uintptr(unsafe.Pointer(termios))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// IsTerminal reports whether fd refers to a terminal.
+func IsTerminal(fd uintptr) bool {
+	_, err := getTermios(fd)
+	return err == nil
+}
+
+// MakeRaw disables line buffering, echo and signal keys on fd so input can
+// be read a key at a time. Output post-processing is left on so "\n" still
+// moves to the start of the next line.
+func MakeRaw(fd uintptr) (*State, error) {
+	termios, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+	oldState := &State{termios: *termios}
+
+	termios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	termios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+	termios.Cflag |= syscall.CS8
+	termios.Cc[syscall.VMIN] = 1
+	termios.Cc[syscall.VTIME] = 0
+
+	if err := setTermios(fd, termios); err != nil {
+		return nil, err
+	}
+	return oldState, nil
+}
+
+// Restore returns fd to the settings captured by MakeRaw.
+func Restore(fd uintptr, state *State) error {
+	return setTermios(fd, &state.termios)
+}
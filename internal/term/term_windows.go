@@ -0,0 +1,23 @@
+package term
+
+import "syscall"
+
+// State is empty on Windows, where raw mode is not supported.
+type State struct{}
+
+// IsTerminal reports whether fd refers to a console. Consoles can't be put
+// into raw mode, but callers can still prompt for input on them.
+func IsTerminal(fd uintptr) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
+
+// MakeRaw is not supported on Windows.
+func MakeRaw(fd uintptr) (*State, error) {
+	return nil, ErrUnsupported
+}
+
+// Restore is a no-op on Windows.
+func Restore(fd uintptr, state *State) error {
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// baseStats collects a Pokémon's base stats.
+func baseStats(pokemon pokeapi.PokemonInfo) battle.Stats {
+	var base battle.Stats
+	for _, stat := range pokemon.Stats {
+		base.Set(stat.Stat.Name, stat.BaseStat)
+	}
+	return base
+}
+
+// effortYield collects the effort values earned by defeating a Pokémon.
+func effortYield(pokemon pokeapi.PokemonInfo) battle.Stats {
+	var yield battle.Stats
+	for _, stat := range pokemon.Stats {
+		yield.Set(stat.Stat.Name, stat.Effort)
+	}
+	return yield
+}
+
+// Stats returns the Pokémon's actual stats at its level, with its nature
+// applied.
+func (p *CaughtPokemon) Stats() battle.Stats {
+	stats := battle.CalcStats(baseStats(p.Info), p.IVs, p.EVs, p.Level)
+	return battle.ApplyNature(stats, p.IncreasedStat, p.DecreasedStat)
+}
+
+// growthRate fetches the experience curve of a species. It returns nil when
+// PokeAPI doesn't list one.
+func (cfg *Config) growthRate(species *pokeapi.PokemonSpecies) (*pokeapi.GrowthRate, error) {
+	if species.GrowthRate.Name == "" {
+		return nil, nil
+	}
+	rate, err := pokeapi.GetGrowthRate(cfg.cache, species.GrowthRate.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch growth rate '%s': %v", species.GrowthRate.Name, err)
+	}
+	return rate, nil
+}
+
+// setExperience gives a newly caught Pokémon the experience its level takes.
+func (cfg *Config) setExperience(pokemon *CaughtPokemon, species *pokeapi.PokemonSpecies) error {
+	rate, err := cfg.growthRate(species)
+	if err != nil || rate == nil {
+		return err
+	}
+	pokemon.Experience = rate.ExperienceAt(pokemon.Level)
+	return nil
+}
+
+// rewardFighter gives the Pokémon that battled wild experience for beating
+// or catching it. Only defeating it earns effort values.
+func (cfg *Config) rewardFighter(wild *wildEncounter, defeated bool) error {
+	fighter, ok := cfg.PokemonCaught[wild.Fighter]
+	if wild.Battle == nil || !ok {
+		return nil
+	}
+	info, err := pokeapi.GetPokemonInfo(cfg.cache, wild.Pokemon)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", wild.Pokemon, err)
+	}
+	if defeated {
+		fighter.EVs = battle.AddEffort(fighter.EVs, effortYield(*info))
+	}
+	return cfg.gainExperience(fighter, battle.ExperienceYield(info.BaseExperience, wild.Level))
+}
+
+// gainExperience adds experience to a Pokémon, levelling it up and evolving
+// it as its species' growth rate and evolution chain allow.
+func (cfg *Config) gainExperience(pokemon *CaughtPokemon, amount int) error {
+	species, err := pokeapi.GetPokemonSpecies(cfg.cache, pokemon.Info.SpeciesName())
+	if err != nil {
+		return fmt.Errorf("failed to fetch species info for '%s': %v", pokemon.Species, err)
+	}
+	rate, err := cfg.growthRate(species)
+	if err != nil || rate == nil || pokemon.Level >= pokeapi.MaxLevel {
+		return err
+	}
+
+	// Pokémon from older saves have a level but no experience yet.
+	pokemon.Experience = max(pokemon.Experience, rate.ExperienceAt(pokemon.Level))
+	pokemon.Experience = min(pokemon.Experience+amount, rate.ExperienceAt(pokeapi.MaxLevel))
+	cfg.notef("%s gained %d Exp. Points!\n", pokemon.Name(), amount)
+
+	level := min(rate.LevelFor(pokemon.Experience), pokeapi.MaxLevel)
+	if level <= pokemon.Level {
+		return nil
+	}
+	for pokemon.Level < level {
+		pokemon.Level++
+		cfg.notef("%s grew to level %d!\n", pokemon.Name(), pokemon.Level)
+	}
+	return cfg.evolve(pokemon, species)
+}
+
+// evolve turns a Pokémon into the next species of its evolution chain when
+// it has reached the level that takes.
+func (cfg *Config) evolve(pokemon *CaughtPokemon, species *pokeapi.PokemonSpecies) error {
+	link, err := cfg.evolutionLink(species)
+	if err != nil || link == nil {
+		return err
+	}
+	if next, ok := link.LevelEvolution(pokemon.Level); ok {
+		info, err := pokeapi.GetPokemonInfo(cfg.cache, next)
+		if err != nil {
+			return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", next, err)
+		}
+		cfg.evolveInto(pokemon, info)
+	}
+	return nil
+}
+
+// evolutionLink finds a species in its evolution chain. It returns nil when
+// the species has no chain.
+func (cfg *Config) evolutionLink(species *pokeapi.PokemonSpecies) (*pokeapi.ChainLink, error) {
+	if species.EvolutionChain.URL == "" {
+		return nil, nil
+	}
+	chain, err := pokeapi.GetEvolutionChain(cfg.cache, species.EvolutionChain.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch evolution chain for '%s': %v", species.Name, err)
+	}
+	return chain.Chain.Find(species.Name), nil
+}
+
+// evolveInto turns a Pokémon into the Pokémon described by next.
+func (cfg *Config) evolveInto(pokemon *CaughtPokemon, next *pokeapi.PokemonInfo) {
+	cfg.notef("What? %s is evolving!\n", pokemon.Name())
+	cfg.notef("%s evolved into %s!\n", pokemon.Name(), next.Name)
+	pokemon.Species = next.Name
+	pokemon.Info = *next
+	cfg.markCaught(next.SpeciesName(), next.SpeciesID())
+}
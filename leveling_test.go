@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func TestCaughtPokemonStats(t *testing.T) {
+	info := pokeapi.PokemonInfo{Name: "pikachu"}
+	info.Stats = []pokeapi.PokemonStat{{BaseStat: 35}, {BaseStat: 90}}
+	info.Stats[0].Stat.Name = "hp"
+	info.Stats[1].Stat.Name = "speed"
+	pokemon := &CaughtPokemon{Species: "pikachu", Level: 50, Info: info}
+
+	if stats := pokemon.Stats(); stats.HP != 95 || stats.Speed != 95 {
+		t.Errorf("Unexpected stats without IVs or EVs: %+v", stats)
+	}
+	pokemon.IVs = battle.Stats{Speed: 31}
+	pokemon.EVs = battle.Stats{Speed: 252}
+	if stats := pokemon.Stats(); stats.Speed != 142 {
+		t.Errorf("Expected IVs and EVs to raise speed to 142, got %d", stats.Speed)
+	}
+}
+
+func TestCatchSetsExperience(t *testing.T) {
+	cfg := createTestConfig()
+	seedPikachu(t, cfg)
+	meetWild(cfg, "pikachu")
+
+	if err := commandCatch(cfg, []string{"pikachu", "master-ball"}); err != nil {
+		t.Fatalf("catch returned an error: %v", err)
+	}
+	caught, err := cfg.findPokemon("2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caught.Level != 5 || caught.Experience != 100 {
+		t.Errorf("Expected the experience of level 5, got level %d with %d", caught.Level, caught.Experience)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// listEndpoints maps the resource names accepted by the list command to
+// their PokeAPI list endpoints.
+var listEndpoints = map[string]string{
+	"pokemon":   pokeapi.EndpointPokemon,
+	"items":     pokeapi.EndpointItems,
+	"moves":     pokeapi.EndpointMoves,
+	"types":     pokeapi.EndpointTypes,
+	"berries":   pokeapi.EndpointBerries,
+	"natures":   pokeapi.EndpointNatures,
+	"regions":   pokeapi.EndpointRegions,
+	"locations": pokeapi.EndpointLocations,
+	"areas":     pokeapi.EndpointLocationAreas,
+}
+
+func commandList(cfg *Config, commands []string) error {
+	args, flags, err := parseArgs(commands)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("list command requires a resource name as an argument (%s)", strings.Join(slices.Sorted(maps.Keys(listEndpoints)), ", "))
+	}
+
+	endpoint, exists := listEndpoints[args[0]]
+	if !exists {
+		return fmt.Errorf("unknown resource '%s', expected one of: %s", args[0], strings.Join(slices.Sorted(maps.Keys(listEndpoints)), ", "))
+	}
+
+	limit := pokeapi.DefaultPageSize
+	if value, set := flags["limit"]; set {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return fmt.Errorf("--limit must be a positive number, got '%s'", value)
+		}
+	}
+
+	resources := []pokeapi.NamedAPIResource{}
+	for resource, err := range pokeapi.Resources(cfg.cache, endpoint, limit) {
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %v", args[0], err)
+		}
+		resources = append(resources, resource)
+		if len(resources) == limit {
+			break
+		}
+	}
+	if handled, err := cfg.emit(resources); handled {
+		return err
+	}
+
+	for _, resource := range resources {
+		fmt.Fprintf(cfg.out, " - %s\n", resource.Name)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCommandListValidation(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandList(cfg, nil); err == nil {
+		t.Error("Expected an error when no resource is given")
+	}
+	if err := commandList(cfg, []string{"gyms"}); err == nil {
+		t.Error("Expected an error for an unknown resource")
+	}
+	if err := commandList(cfg, []string{"types", "--limit", "zero"}); err == nil {
+		t.Error("Expected an error for a non-numeric limit")
+	}
+}
+
+func TestCommandListCached(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/type?offset=0&limit=2", []byte(`{
+		"count": 20,
+		"next": "https://pokeapi.co/api/v2/type?offset=2&limit=2",
+		"results": [{"name": "normal", "url": ""}, {"name": "fighting", "url": ""}]
+	}`))
+
+	// Only the first page is cached, so a second fetch would fail offline.
+	if err := commandList(cfg, []string{"types", "--limit", "2"}); err != nil {
+		t.Errorf("list returned an error: %v", err)
+	}
+}
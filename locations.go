@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func commandRegions(cfg *Config, commands []string) error {
+	regions := []pokeapi.NamedAPIResource{}
+	for region, err := range pokeapi.Resources(cfg.cache, pokeapi.EndpointRegions, pokeapi.DefaultPageSize) {
+		if err != nil {
+			return fmt.Errorf("failed to fetch regions: %v", err)
+		}
+		regions = append(regions, region)
+	}
+	if handled, err := cfg.emit(regions); handled {
+		return err
+	}
+
+	fmt.Fprintln(cfg.out, "Regions:")
+	for _, region := range regions {
+		fmt.Fprintf(cfg.out, " - %s\n", region.Name)
+	}
+	return nil
+}
+
+func commandRegion(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("region command requires a region name as an argument")
+	}
+
+	regionName := commands[0]
+	region, err := pokeapi.GetRegion(cfg.cache, regionName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch region '%s': %v", regionName, err)
+	}
+	if handled, err := cfg.emit(region); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Region: %s (%s)\n", region.Name, region.MainGeneration.Name)
+	fmt.Fprintf(cfg.out, "Locations in %s:\n", region.Name)
+	for _, location := range region.Locations {
+		fmt.Fprintf(cfg.out, " - %s\n", location.Name)
+	}
+	return nil
+}
+
+func commandLocation(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("location command requires a location name as an argument")
+	}
+
+	locationName := commands[0]
+	location, err := pokeapi.GetLocation(cfg.cache, locationName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch location '%s': %v", locationName, err)
+	}
+	if handled, err := cfg.emit(location); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Location: %s\n", location.Name)
+	if location.Region != nil {
+		fmt.Fprintf(cfg.out, "Region: %s\n", location.Region.Name)
+	}
+	if len(location.Areas) == 0 {
+		fmt.Fprintln(cfg.out, "This location has no explorable areas.")
+		return nil
+	}
+	fmt.Fprintln(cfg.out, "Areas (use explore <area> to look around):")
+	for _, area := range location.Areas {
+		fmt.Fprintf(cfg.out, " - %s\n", area.Name)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCommandRegionRequiresName(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandRegion(cfg, nil); err == nil {
+		t.Error("Expected an error when no region name is given")
+	}
+	if err := commandLocation(cfg, nil); err == nil {
+		t.Error("Expected an error when no location name is given")
+	}
+}
+
+func TestCommandLocationCached(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/location/kanto-route-1", []byte(`{
+		"id": 88,
+		"name": "kanto-route-1",
+		"region": {"name": "kanto", "url": ""},
+		"areas": [{"name": "kanto-route-1-area", "url": ""}]
+	}`))
+
+	if err := commandLocation(cfg, []string{"kanto-route-1"}); err != nil {
+		t.Errorf("location command returned an error: %v", err)
+	}
+}
+
+func TestCommandRegionsCached(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/region?offset=0&limit=20", []byte(`{
+		"count": 2,
+		"results": [{"name": "kanto", "url": ""}, {"name": "johto", "url": ""}]
+	}`))
+
+	if err := commandRegions(cfg, nil); err != nil {
+		t.Errorf("regions command returned an error: %v", err)
+	}
+}
@@ -1,53 +1,137 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
+	"github.com/OttScott/pokedexcli/internal/output"
 	"github.com/OttScott/pokedexcli/internal/pokecache"
 	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
 )
 
 type Config struct {
 	cache			    *pokecache.Cache
-	NextLocationURL     *string
-	PreviousLocationURL *string
-	PokemonCaught       map[string]pokeapi.PokemonInfo
+	LocationOffset      int  // Offset of the location area page last shown
+	LocationLimit       int  // Number of location areas per page
+	LocationCount       int  // Total location areas, known after the first fetch
+	LocationPageShown   bool // Whether map has shown any page yet
+	PokemonCaught       map[string]*CaughtPokemon // Every Pokémon owned, by ID
+	Party               []string                  // IDs of the Pokémon carried, lead first
+	Boxes               [][]string                // IDs of the Pokémon stored in each PC box
+	CurrentArea         string         // Location area set by explore or goto
+	ExploredAreas       []string       // Every location area visited
+	Pokedex             map[string]DexEntry // Species seen or caught, by name
+	Trainer             Trainer
+	sessionStart        time.Time      // When play time started counting this session
+	Wild                *wildEncounter // The wild Pokémon being faced, if any
+	Bag                 Inventory
+	AutoCorrect         bool   // Retry failed lookups with the closest known name
+	SavePath            string // Where the session is saved; empty disables saving
+	Output              output.Format // How command results are written
+	Seed                uint64        // Seed of the session's random numbers
+	rng                 *rand.Rand
+	rngSource           *rand.PCG
+	rngResumed          bool // Whether rng continues a saved sequence rather than starting at Seed
+	out                 io.Writer
+	errOut              io.Writer // Where notices go in structured output modes
+	style               render.Style // Whether output to out is colored
+	nameIndex           map[string][]string
+}
+
+func newConfig(cache *pokecache.Cache) *Config {
+	cfg := &Config{
+		LocationLimit:       pokeapi.DefaultPageSize,
+		cache:               cache,
+		PokemonCaught:       make(map[string]*CaughtPokemon),
+		Bag:                 newStarterInventory(),
+		Trainer:             newTrainer(),
+		Pokedex:             make(map[string]DexEntry),
+		sessionStart:        time.Now(),
+		Output:              output.Text,
+		nameIndex:           make(map[string][]string),
+		out:                 os.Stdout,
+		errOut:              os.Stderr,
+		style:               render.Detect(os.Stdout),
+	}
+	cfg.reseed(rand.Uint64())
+	return cfg
 }
 
 func main() {
+	scriptPath := flag.String("f", "", "execute REPL commands from a script file instead of prompting")
+	savePath := flag.String("save", defaultSavePath(), "save file to load on start and write on exit (empty to disable)")
+	debug := flag.Bool("debug", false, "log PokeAPI requests to stderr")
+	seed := flag.Uint64("seed", 0, "seed for random numbers, to replay a session exactly (default random)")
+	outputFormat := flag.String("output", string(output.Text), "output format: text, json, yaml or csv")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-f script] [-save file] [-output format] [-seed n] [command [args...]]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	format, err := output.ParseFormat(*outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *debug {
+		pokeapi.SetLogger(log.New(os.Stderr, "pokeapi: ", log.LstdFlags))
+	}
+
 	cache := pokecache.NewCache(time.Second * 30)
 
-	config := &Config{
-		NextLocationURL:     nil,  // No next URL yet
-		PreviousLocationURL: nil,  // No previous URL yet
-		cache:               cache,
-		PokemonCaught:       make(map[string]pokeapi.PokemonInfo),
-	}
-
-	// Basic REPL loop
-	scanner := bufio.NewScanner(os.Stdin)
-	for {
-		fmt.Print("POKEDEX > ")
-		scanner.Scan()
-		input := scanner.Text()
-		command := cleanInput(input)
-		if len(command) == 0 {
-			continue
-		}
-		if command[0] == "help" {
-			commandHelp(config, command[1:])
-			continue
-		}
-		cmd, exists := commands_map[command[0]]
-		if !exists {
-			fmt.Println("Unknown command.")
-			continue
+	config := newConfig(cache)
+	config.SavePath = *savePath
+	config.Output = format
+	if err := config.loadGame(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			config.reseed(*seed)
 		}
-		if err := cmd.callback(config, command[1:]); err != nil {
-			fmt.Printf("Error executing command '%s': %v\n", command, err)
+	})
+
+	repl := newREPL(config)
+	if path, err := historyPath(); err == nil {
+		repl.historyPath = path
+	}
+
+	switch {
+	case flag.NArg() > 0:
+		err = repl.Run(strings.NewReader(strings.Join(flag.Args(), " ")), os.Stdout)
+	case *scriptPath != "":
+		var script *os.File
+		script, err = os.Open(*scriptPath)
+		if err != nil {
+			err = fmt.Errorf("failed to open script: %v", err)
+			break
 		}
+		defer script.Close()
+		err = repl.Run(script, os.Stdout)
+	default:
+		err = repl.Run(os.Stdin, os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
 
+// historyPath returns the file REPL history is kept in across sessions.
+func historyPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(home, ".pokedexcli_history"), nil
 }
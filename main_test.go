@@ -9,23 +9,19 @@ import (
 func TestConfigInitialization(t *testing.T) {
 	cache := pokecache.NewCache(time.Second * 5)
 	
-	config := &Config{
-		NextLocationURL:     nil,
-		PreviousLocationURL: nil,
-		cache:               cache,
-	}
+	config := newConfig(cache)
 
 	// Test that config is properly initialized
 	if config.cache == nil {
 		t.Error("Cache should not be nil")
 	}
 	
-	if config.NextLocationURL != nil {
-		t.Error("NextLocationURL should be nil initially")
+	if config.LocationOffset != 0 || config.LocationPageShown {
+		t.Error("Location paging should start before the first page")
 	}
 	
-	if config.PreviousLocationURL != nil {
-		t.Error("PreviousLocationURL should be nil initially")
+	if config.LocationLimit != 20 {
+		t.Errorf("LocationLimit should default to 20, got %d", config.LocationLimit)
 	}
 }
 
@@ -121,7 +117,7 @@ func TestCommandsInitialization(t *testing.T) {
 	expectedCommands := []string{"exit", "map", "mapb"}
 	
 	for _, cmdName := range expectedCommands {
-		cmd, exists := commands[cmdName]
+		cmd, exists := commands_map[cmdName]
 		if !exists {
 			t.Errorf("Command '%s' should exist in commands map", cmdName)
 		}
@@ -143,14 +139,10 @@ func TestCommandsInitialization(t *testing.T) {
 // Example of testing command functionality (you'd need to mock HTTP calls for full testing)
 func TestCommandHelpFunction(t *testing.T) {
 	cache := pokecache.NewCache(time.Second * 5)
-	config := &Config{
-		NextLocationURL:     nil,
-		PreviousLocationURL: nil,
-		cache:               cache,
-	}
+	config := newConfig(cache)
 	
 	// Test that commandHelp doesn't return an error
-	err := commandHelp(config)
+	err := commandHelp(config, nil)
 	if err != nil {
 		t.Errorf("commandHelp should not return an error, got: %v", err)
 	}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
+)
+
+const (
+	// partySize is how many Pokémon the player can carry.
+	partySize = 6
+	// boxSize is how many Pokémon fit in one PC box.
+	boxSize = 30
+	// maxNicknameLength matches the limit in the mainline games.
+	maxNicknameLength = 12
+)
+
+var errPokemonNotCaught = errors.New("you have not caught that pokemon")
+
+// CaughtPokemon is one Pokémon the player owns. Each has its own ID, so the
+// player can own several of the same species.
+type CaughtPokemon struct {
+	ID            string       `json:"id"`      // Random 8-digit hex ID
+	Species       string       `json:"species"` // PokeAPI Pokémon name, e.g. pikachu
+	Nickname      string       `json:"nickname,omitempty"`
+	Level         int          `json:"level"`
+	Experience    int          `json:"experience"` // Total experience, on the species' growth rate
+	IVs           battle.Stats `json:"ivs"`
+	EVs           battle.Stats `json:"evs"`
+	Nature        string       `json:"nature,omitempty"`
+	IncreasedStat string       `json:"increased_stat,omitempty"` // Stat raised by the nature, if any
+	DecreasedStat string       `json:"decreased_stat,omitempty"` // Stat lowered by the nature, if any
+	Gender        string       `json:"gender,omitempty"`         // male, female or genderless
+	Shiny         bool         `json:"shiny,omitempty"`
+	CaughtAt      time.Time    `json:"caught_at"`
+	Location      string       `json:"location,omitempty"` // Location area it was caught in
+	// OriginalTrainer is who caught the Pokémon, once it has been traded.
+	OriginalTrainer string              `json:"original_trainer,omitempty"`
+	Info            pokeapi.PokemonInfo `json:"info"`
+}
+
+// Name is the nickname, or the species when the Pokémon has none.
+func (p *CaughtPokemon) Name() string {
+	if p.Nickname != "" {
+		return p.Nickname
+	}
+	return p.Species
+}
+
+// newPokemonID returns an ID no caught Pokémon has yet. IDs come from the
+// session's random source, so a seeded session hands out the same IDs.
+func (cfg *Config) newPokemonID() string {
+	for {
+		id := fmt.Sprintf("%08x", cfg.rng.Uint32())
+		if _, taken := cfg.PokemonCaught[id]; !taken && validPokemonID(id) {
+			return id
+		}
+	}
+}
+
+// validPokemonID reports whether id looks like one newPokemonID hands out:
+// eight lowercase hex digits, at least one of them a letter so the ID can't
+// be mistaken for a party slot.
+func validPokemonID(id string) bool {
+	if len(id) != 8 {
+		return false
+	}
+	letter := false
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'f':
+			letter = true
+		case r < '0' || r > '9':
+			return false
+		}
+	}
+	return letter
+}
+
+// addCaught stores a newly caught Pokémon in the party, or in the first box
+// with room when the party is full. It returns where the Pokémon went.
+func (cfg *Config) addCaught(pokemon *CaughtPokemon) string {
+	cfg.PokemonCaught[pokemon.ID] = pokemon
+	cfg.markCaught(pokemon.Info.SpeciesName(), pokemon.Info.SpeciesID())
+	if len(cfg.Party) < partySize {
+		cfg.Party = append(cfg.Party, pokemon.ID)
+		return "your party"
+	}
+	box := cfg.boxWithRoom()
+	cfg.Boxes[box] = append(cfg.Boxes[box], pokemon.ID)
+	return fmt.Sprintf("box %d", box+1)
+}
+
+// boxWithRoom returns the index of the first box that isn't full, adding a
+// new box when they all are.
+func (cfg *Config) boxWithRoom() int {
+	for i, box := range cfg.Boxes {
+		if len(box) < boxSize {
+			return i
+		}
+	}
+	cfg.Boxes = append(cfg.Boxes, nil)
+	return len(cfg.Boxes) - 1
+}
+
+// locate returns the list holding a Pokémon, the party or one of the boxes,
+// and its index there. The list is nil for unknown IDs.
+func (cfg *Config) locate(id string) (*[]string, int) {
+	if i := slices.Index(cfg.Party, id); i >= 0 {
+		return &cfg.Party, i
+	}
+	for b := range cfg.Boxes {
+		if i := slices.Index(cfg.Boxes[b], id); i >= 0 {
+			return &cfg.Boxes[b], i
+		}
+	}
+	return nil, -1
+}
+
+// findPokemon resolves what the player typed to one of their Pokémon: a
+// party slot number, an ID, a nickname or a species name. Names matching
+// several Pokémon are rejected so the player can pick one by ID.
+func (cfg *Config) findPokemon(ref string) (*CaughtPokemon, error) {
+	// Nicknames keep their case, but the player can type them in any case.
+	typed := ref
+	ref = strings.ToLower(ref)
+	if slot, err := strconv.Atoi(ref); err == nil && slot >= 1 && slot <= len(cfg.Party) {
+		return cfg.PokemonCaught[cfg.Party[slot-1]], nil
+	}
+	if pokemon, ok := cfg.PokemonCaught[ref]; ok {
+		return pokemon, nil
+	}
+
+	var matches []*CaughtPokemon
+	for _, pokemon := range cfg.PokemonCaught {
+		if strings.EqualFold(pokemon.Nickname, ref) || (pokemon.Nickname == "" && pokemon.Species == ref) {
+			matches = append(matches, pokemon)
+		}
+	}
+	if len(matches) == 0 {
+		for _, pokemon := range cfg.PokemonCaught {
+			if pokemon.Species == ref {
+				matches = append(matches, pokemon)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errPokemonNotCaught
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, pokemon := range matches {
+		ids[i] = pokemon.ID
+	}
+	slices.Sort(ids)
+	return nil, fmt.Errorf("you have %d Pokémon called %s; use one of their IDs: %s", len(matches), typed, strings.Join(ids, ", "))
+}
+
+// caughtNames lists the names the player knows their Pokémon by, for
+// suggestions and completion.
+func (cfg *Config) caughtNames() []string {
+	var names []string
+	for _, pokemon := range cfg.PokemonCaught {
+		if !slices.Contains(names, pokemon.Name()) {
+			names = append(names, pokemon.Name())
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// partyPokemon returns the Pokémon in the party, in order.
+func (cfg *Config) partyPokemon() []*CaughtPokemon {
+	party := make([]*CaughtPokemon, 0, len(cfg.Party))
+	for _, id := range cfg.Party {
+		party = append(party, cfg.PokemonCaught[id])
+	}
+	return party
+}
+
+func (cfg *Config) writePokemonTable(ids []string) error {
+	table := render.NewTable(cfg.style.Bold("SLOT"), cfg.style.Bold("ID"), cfg.style.Bold("NAME"),
+		cfg.style.Bold("SPECIES"), cfg.style.Bold("LV"), cfg.style.Bold("TYPES"))
+	table.Indent = "  "
+	for i, id := range ids {
+		pokemon := cfg.PokemonCaught[id]
+		table.AddRow(fmt.Sprint(i+1), pokemon.ID, pokemon.Name(), pokemon.Species,
+			fmt.Sprint(pokemon.Level), cfg.style.Types(typeNames(pokemon.Info)))
+	}
+	return table.Write(cfg.out)
+}
+
+func commandParty(cfg *Config, commands []string) error {
+	records := []caughtRecord{}
+	for _, pokemon := range cfg.partyPokemon() {
+		records = append(records, newCaughtRecord(pokemon))
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	if len(cfg.Party) == 0 {
+		fmt.Fprintln(cfg.out, "Your party is empty. Catch some Pokémon!")
+		return nil
+	}
+	fmt.Fprintf(cfg.out, "Your party (%d/%d):\n", len(cfg.Party), partySize)
+	return cfg.writePokemonTable(cfg.Party)
+}
+
+func commandBox(cfg *Config, commands []string) error {
+	boxes := make([]int, 0, len(cfg.Boxes))
+	if len(commands) > 0 {
+		box, err := cfg.parseBox(commands[0])
+		if err != nil {
+			return err
+		}
+		boxes = append(boxes, box)
+	} else {
+		for i, box := range cfg.Boxes {
+			if len(box) > 0 {
+				boxes = append(boxes, i)
+			}
+		}
+	}
+
+	records := []caughtRecord{}
+	for _, box := range boxes {
+		for _, id := range cfg.Boxes[box] {
+			record := newCaughtRecord(cfg.PokemonCaught[id])
+			record.Box = box + 1
+			records = append(records, record)
+		}
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(cfg.out, "Your boxes are empty.")
+		return nil
+	}
+	for _, box := range boxes {
+		fmt.Fprintf(cfg.out, "Box %d (%d/%d):\n", box+1, len(cfg.Boxes[box]), boxSize)
+		if err := cfg.writePokemonTable(cfg.Boxes[box]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseBox converts a 1-based box number typed by the player to an index.
+func (cfg *Config) parseBox(arg string) (int, error) {
+	box, err := strconv.Atoi(arg)
+	if err != nil || box < 1 || box > len(cfg.Boxes) {
+		return 0, fmt.Errorf("there is no box %s; you have %d", arg, len(cfg.Boxes))
+	}
+	return box - 1, nil
+}
+
+func commandDeposit(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("deposit command requires a Pokémon from your party, optionally followed by a box number")
+	}
+	pokemon, err := cfg.findPokemon(commands[0])
+	if err != nil {
+		return err
+	}
+	slot := slices.Index(cfg.Party, pokemon.ID)
+	if slot < 0 {
+		return fmt.Errorf("%s is not in your party", pokemon.Name())
+	}
+	if len(cfg.Party) == 1 {
+		return fmt.Errorf("you can't deposit your last Pokémon")
+	}
+
+	var box int
+	if len(commands) > 1 {
+		if box, err = cfg.parseBox(commands[1]); err != nil {
+			return err
+		}
+		if len(cfg.Boxes[box]) >= boxSize {
+			return fmt.Errorf("box %d is full", box+1)
+		}
+	} else {
+		box = cfg.boxWithRoom()
+	}
+
+	cfg.Party = slices.Delete(cfg.Party, slot, slot+1)
+	cfg.Boxes[box] = append(cfg.Boxes[box], pokemon.ID)
+	cfg.notef("%s was deposited in box %d.\n", pokemon.Name(), box+1)
+	return nil
+}
+
+func commandWithdraw(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("withdraw command requires a Pokémon from your boxes")
+	}
+	pokemon, err := cfg.findPokemon(commands[0])
+	if err != nil {
+		return err
+	}
+	list, i := cfg.locate(pokemon.ID)
+	if list == &cfg.Party {
+		return fmt.Errorf("%s is already in your party", pokemon.Name())
+	}
+	if len(cfg.Party) >= partySize {
+		return fmt.Errorf("your party is full; deposit a Pokémon first")
+	}
+
+	*list = slices.Delete(*list, i, i+1)
+	cfg.Party = append(cfg.Party, pokemon.ID)
+	cfg.notef("%s joined your party.\n", pokemon.Name())
+	return nil
+}
+
+// commandSwap exchanges the places of two Pokémon, in the party, between
+// the party and a box, or between boxes.
+func commandSwap(cfg *Config, commands []string) error {
+	if len(commands) < 2 {
+		return fmt.Errorf("swap command requires two Pokémon, e.g. swap 1 3")
+	}
+	first, err := cfg.findPokemon(commands[0])
+	if err != nil {
+		return err
+	}
+	second, err := cfg.findPokemon(commands[1])
+	if err != nil {
+		return err
+	}
+
+	firstList, i := cfg.locate(first.ID)
+	secondList, j := cfg.locate(second.ID)
+	(*firstList)[i], (*secondList)[j] = (*secondList)[j], (*firstList)[i]
+	cfg.notef("%s and %s swapped places.\n", first.Name(), second.Name())
+	return nil
+}
+
+func commandNickname(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("nickname command requires a Pokémon, optionally followed by its new nickname")
+	}
+	pokemon, err := cfg.findPokemon(commands[0])
+	if err != nil {
+		return err
+	}
+
+	if len(commands) < 2 {
+		pokemon.Nickname = ""
+		cfg.notef("%s's nickname was removed.\n", pokemon.Species)
+		return nil
+	}
+
+	nickname := commands[1]
+	if err := checkNickname(nickname); err != nil {
+		return err
+	}
+	pokemon.Nickname = nickname
+	cfg.notef("%s is now called %s.\n", pokemon.Species, nickname)
+	return nil
+}
+
+// checkName rejects a trainer name or nickname that is empty, too long or
+// has characters that can't be printed.
+func checkName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("a %s can't be empty", kind)
+	}
+	if len([]rune(name)) > maxNicknameLength {
+		return fmt.Errorf("a %s can be at most %d characters", kind, maxNicknameLength)
+	}
+	if strings.ContainsFunc(name, func(r rune) bool { return !unicode.IsPrint(r) }) {
+		return fmt.Errorf("a %s can only contain printable characters", kind)
+	}
+	return nil
+}
+
+// checkNickname rejects a nickname that checkName does, or that findPokemon
+// could mistake for a party slot or an ID.
+func checkNickname(nickname string) error {
+	if _, err := strconv.Atoi(nickname); err == nil {
+		return fmt.Errorf("a nickname can't be a number")
+	}
+	if validPokemonID(strings.ToLower(nickname)) {
+		return fmt.Errorf("'%s' looks like a Pokémon ID", nickname)
+	}
+	return checkName("nickname", nickname)
+}
+
+// checkCanPart reports why the player can't give up a Pokémon, if they
+// can't: it is their last one in the party, or it is battling.
+func (cfg *Config) checkCanPart(pokemon *CaughtPokemon) error {
+	if len(cfg.Party) == 1 && cfg.Party[0] == pokemon.ID {
+		return fmt.Errorf("you can't part with your last Pokémon in your party")
+	}
+	if cfg.Wild != nil && cfg.Wild.Battle != nil && cfg.Wild.Fighter == pokemon.ID {
+		return fmt.Errorf("%s is in the middle of a battle", pokemon.Name())
+	}
+	return nil
+}
+
+// removePokemon takes a Pokémon out of the party or its box and forgets it.
+// The Pokédex still records its species as caught.
+func (cfg *Config) removePokemon(pokemon *CaughtPokemon) {
+	if list, i := cfg.locate(pokemon.ID); list != nil {
+		*list = slices.Delete(*list, i, i+1)
+	}
+	delete(cfg.PokemonCaught, pokemon.ID)
+}
+
+func commandRelease(cfg *Config, commands []string) error {
+	args, flags, err := parseArgs(commands, "yes")
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("release command requires a Pokémon as an argument")
+	}
+	pokemon, err := cfg.findPokemon(args[0])
+	if err != nil {
+		return err
+	}
+	if err := cfg.checkCanPart(pokemon); err != nil {
+		return err
+	}
+
+	if flags["yes"] == "" {
+		cfg.notef("Release %s (%s, Lv. %d, ID %s)? It can't be undone.\n", pokemon.Name(), pokemon.Species, pokemon.Level, pokemon.ID)
+		cfg.notef("Run release %s --yes to confirm.\n", pokemon.ID)
+		return nil
+	}
+	cfg.removePokemon(pokemon)
+	cfg.notef("%s was released. Bye, %s!\n", pokemon.Name(), pokemon.Name())
+	return nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// givePokemon adds info to the player's Pokémon as if it had just been
+// caught at level 5.
+func givePokemon(cfg *Config, info pokeapi.PokemonInfo) *CaughtPokemon {
+	pokemon := &CaughtPokemon{ID: cfg.newPokemonID(), Species: info.Name, Level: 5, CaughtAt: time.Now(), Info: info}
+	cfg.addCaught(pokemon)
+	return pokemon
+}
+
+// hasCaught reports whether the player owns any Pokémon of species.
+func hasCaught(cfg *Config, species string) bool {
+	for _, pokemon := range cfg.PokemonCaught {
+		if pokemon.Species == species {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCatchSameSpeciesTwice(t *testing.T) {
+	cfg := createTestConfig()
+	seedPokemon(cfg, "pikachu", 112)
+	cfg.Bag["master-ball"] = 2
+
+	for range 2 {
+		meetWild(cfg, "pikachu")
+		if err := commandCatch(cfg, []string{"pikachu", "master-ball"}); err != nil {
+			t.Fatalf("catch returned an error: %v", err)
+		}
+	}
+	if len(cfg.PokemonCaught) != 2 || len(cfg.Party) != 2 || cfg.Party[0] == cfg.Party[1] {
+		t.Fatalf("Expected two separate pikachu in the party, got %v", cfg.Party)
+	}
+	if first := cfg.PokemonCaught[cfg.Party[0]]; first.Level != 5 {
+		t.Errorf("Expected pikachu to keep the level it was met at, got %d", first.Level)
+	}
+
+	if _, err := cfg.findPokemon("pikachu"); err == nil || !strings.Contains(err.Error(), cfg.Party[0]) {
+		t.Errorf("Expected an ambiguous name to list the IDs, got %v", err)
+	}
+	if pokemon, err := cfg.findPokemon("2"); err != nil || pokemon.ID != cfg.Party[1] {
+		t.Errorf("Expected party slot 2 to resolve, got %v, %v", pokemon, err)
+	}
+}
+
+func TestPartyOverflowsIntoBoxes(t *testing.T) {
+	cfg := createTestConfig()
+	for i := range partySize + 2 {
+		givePokemon(cfg, pokeapi.PokemonInfo{ID: i + 1, Name: fmt.Sprintf("pokemon-%d", i+1)})
+	}
+	if len(cfg.Party) != partySize || len(cfg.Boxes) != 1 || len(cfg.Boxes[0]) != 2 {
+		t.Fatalf("Expected a full party and 2 boxed Pokémon, got %v and %v", cfg.Party, cfg.Boxes)
+	}
+
+	if err := commandWithdraw(cfg, []string{"pokemon-7"}); err == nil {
+		t.Error("Expected an error withdrawing into a full party")
+	}
+	if err := commandDeposit(cfg, []string{"pokemon-1"}); err != nil {
+		t.Fatalf("deposit returned an error: %v", err)
+	}
+	if err := commandWithdraw(cfg, []string{"pokemon-7"}); err != nil {
+		t.Fatalf("withdraw returned an error: %v", err)
+	}
+	if !slices.ContainsFunc(cfg.partyPokemon(), func(p *CaughtPokemon) bool { return p.Species == "pokemon-7" }) {
+		t.Errorf("Expected pokemon-7 in the party, got %v", cfg.Party)
+	}
+	if list, _ := cfg.locate(cfg.Boxes[0][len(cfg.Boxes[0])-1]); list == &cfg.Party {
+		t.Error("Expected the deposited Pokémon to be in a box")
+	}
+}
+
+func TestDepositKeepsLastPokemon(t *testing.T) {
+	cfg := createTestConfig()
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+
+	if err := commandDeposit(cfg, []string{"pikachu"}); err == nil {
+		t.Error("Expected an error depositing the last party Pokémon")
+	}
+}
+
+func TestSwapAndNickname(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	bulbasaur := givePokemon(cfg, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	pikachu := givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+
+	if err := commandSwap(cfg, []string{"1", "2"}); err != nil {
+		t.Fatalf("swap returned an error: %v", err)
+	}
+	if !slices.Equal(cfg.Party, []string{pikachu.ID, bulbasaur.ID}) {
+		t.Errorf("Expected the party to be swapped, got %v", cfg.Party)
+	}
+
+	for _, bad := range []string{"42", "averyverylongname"} {
+		if err := commandNickname(cfg, []string{"pikachu", bad}); err == nil {
+			t.Errorf("Expected nickname %q to be rejected", bad)
+		}
+	}
+	if err := commandNickname(cfg, []string{"pikachu", "sparky"}); err != nil {
+		t.Fatalf("nickname returned an error: %v", err)
+	}
+	if pokemon, err := cfg.findPokemon("sparky"); err != nil || pokemon != pikachu {
+		t.Errorf("Expected to find pikachu by its nickname, got %v, %v", pokemon, err)
+	}
+
+	out.Reset()
+	if err := commandParty(cfg, nil); err != nil {
+		t.Fatalf("party returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "sparky") || !strings.Contains(out.String(), "Your party (2/6):") {
+		t.Errorf("Unexpected party output: %q", out.String())
+	}
+
+	if err := commandNickname(cfg, []string{"sparky"}); err != nil {
+		t.Fatalf("nickname returned an error: %v", err)
+	}
+	if pikachu.Name() != "pikachu" {
+		t.Errorf("Expected the nickname to be removed, got %s", pikachu.Name())
+	}
+}
+
+func TestValidPokemonID(t *testing.T) {
+	for id, want := range map[string]bool{
+		"0a1b2c3d": true,
+		"00000003": false,
+		"1":        false,
+		"0A1B2C3D": false,
+		"0a1b2c3g": false,
+	} {
+		if got := validPokemonID(id); got != want {
+			t.Errorf("validPokemonID(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestNicknameKeepsCase(t *testing.T) {
+	cfg := createTestConfig()
+	pikachu := givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+
+	if err := executeCommand(cfg, "nickname Pikachu Sparky"); err != nil {
+		t.Fatalf("nickname returned an error: %v", err)
+	}
+	if pikachu.Nickname != "Sparky" {
+		t.Errorf("Expected the nickname as typed, got %q", pikachu.Nickname)
+	}
+	if err := executeCommand(cfg, "nickname SPARKY "+strings.ToUpper(pikachu.ID)); err == nil {
+		t.Error("Expected a nickname matching an ID in another case to be rejected")
+	}
+}
+
+func TestReleaseNeedsConfirmation(t *testing.T) {
+	cfg := createTestConfig()
+	bulbasaur := givePokemon(cfg, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	pikachu := givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+
+	if err := commandRelease(cfg, []string{"pikachu"}); err != nil {
+		t.Fatalf("release returned an error: %v", err)
+	}
+	if _, kept := cfg.PokemonCaught[pikachu.ID]; !kept {
+		t.Fatal("Expected release without --yes to only ask for confirmation")
+	}
+	if err := commandRelease(cfg, []string{pikachu.ID, "--yes"}); err != nil {
+		t.Fatalf("release --yes returned an error: %v", err)
+	}
+	if _, kept := cfg.PokemonCaught[pikachu.ID]; kept || len(cfg.Party) != 1 {
+		t.Errorf("Expected pikachu to be released, party is %v", cfg.Party)
+	}
+	if status := cfg.dexStatus("pikachu"); status != "caught" {
+		t.Errorf("Expected pikachu to stay caught in the Pokédex, got %s", status)
+	}
+
+	if err := commandRelease(cfg, []string{bulbasaur.ID, "--yes"}); err == nil {
+		t.Error("Expected an error releasing the last Pokémon in the party")
+	}
+}
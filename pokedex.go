@@ -0,0 +1,220 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
+)
+
+// DexEntry records a species the player has seen, and whether they have
+// ever caught one.
+type DexEntry struct {
+	ID     int  `json:"id"` // National Pokédex number, 0 if not known yet
+	Caught bool `json:"caught,omitempty"`
+	// Pending marks an entry recorded under the name of a Pokémon met in an
+	// area, which may be a form, until its species is looked up.
+	Pending bool `json:"pending,omitempty"`
+}
+
+// markSeen adds a species to the Pokédex as seen.
+func (cfg *Config) markSeen(species string, id int) {
+	entry := cfg.Pokedex[species]
+	if entry.ID == 0 || entry.Pending {
+		entry.ID = id
+	}
+	entry.Pending = false
+	cfg.Pokedex[species] = entry
+}
+
+// markCaught records that the player has caught a species. It stays caught
+// even if every one of them is later released.
+func (cfg *Config) markCaught(species string, id int) {
+	cfg.markSeen(species, id)
+	entry := cfg.Pokedex[species]
+	entry.Caught = true
+	cfg.Pokedex[species] = entry
+}
+
+// markEncountersSeen adds the Pokémon listed in encounters to the Pokédex
+// as seen. Areas name Pokémon, which may be forms, so they are recorded as
+// pending and only looked up by resolvePokedex when the Pokédex is shown.
+func (cfg *Config) markEncountersSeen(area *pokeapi.LocationAreaDetail, encounters []pokeapi.EncounterSummary) {
+	met := make(map[string]bool)
+	for _, encounter := range encounters {
+		met[encounter.Pokemon] = true
+	}
+	for _, encounter := range area.PokemonEncounters {
+		name := encounter.Pokemon.Name
+		if _, known := cfg.Pokedex[name]; known || !met[name] {
+			continue
+		}
+		cfg.Pokedex[name] = DexEntry{ID: pokeapi.ResourceID(encounter.Pokemon.URL), Pending: true}
+	}
+}
+
+// resolvePokedex replaces the pending entries of the Pokédex with the
+// species of the Pokémon they were recorded for.
+func (cfg *Config) resolvePokedex() error {
+	for _, name := range slices.Sorted(maps.Keys(cfg.Pokedex)) {
+		if !cfg.Pokedex[name].Pending {
+			continue
+		}
+		info, err := pokeapi.GetPokemonInfo(cfg.cache, name)
+		if err != nil {
+			return fmt.Errorf("failed to fetch pokemon info for '%s': %v", name, err)
+		}
+		delete(cfg.Pokedex, name)
+		cfg.markSeen(info.SpeciesName(), info.SpeciesID())
+	}
+	return nil
+}
+
+func (cfg *Config) dexStatus(species string) string {
+	entry, seen := cfg.Pokedex[species]
+	switch {
+	case entry.Caught:
+		return "caught"
+	case seen:
+		return "seen"
+	}
+	return "missing"
+}
+
+// percent formats part as a percentage of total.
+func percent(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+func commandPokedex(cfg *Config, commands []string) error {
+	_, flags, err := parseArgs(commands, "missing")
+	if err != nil {
+		return err
+	}
+	if err := cfg.resolvePokedex(); err != nil {
+		return err
+	}
+	if flags["region"] != "" || flags["dex"] != "" {
+		return cfg.showRegionalPokedex(flags["region"], flags["dex"], flags["missing"] != "")
+	}
+	if flags["missing"] != "" {
+		return fmt.Errorf("--missing needs a Pokédex to compare against; add --region <name> or --dex <name>")
+	}
+
+	names := slices.SortedFunc(maps.Keys(cfg.Pokedex), func(a, b string) int {
+		return cmp.Or(cmp.Compare(cfg.Pokedex[a].ID, cfg.Pokedex[b].ID), cmp.Compare(a, b))
+	})
+	records := []pokedexRecord{}
+	caught := 0
+	for _, name := range names {
+		records = append(records, pokedexRecord{ID: cfg.Pokedex[name].ID, Name: name, Status: cfg.dexStatus(name)})
+		if cfg.Pokedex[name].Caught {
+			caught++
+		}
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(cfg.out, "You haven't seen any Pokémon yet!")
+		return nil
+	}
+
+	// Types are known for the species the player owns.
+	types := make(map[string][]string)
+	for _, pokemon := range cfg.PokemonCaught {
+		types[pokemon.Info.SpeciesName()] = typeNames(pokemon.Info)
+	}
+
+	fmt.Fprintf(cfg.out, "Your Pokedex (%d seen, %d caught):\n", len(records), caught)
+	table := render.NewTable(cfg.style.Bold("#"), cfg.style.Bold("NAME"), cfg.style.Bold("STATUS"), cfg.style.Bold("TYPES"))
+	table.Indent = "  "
+	for _, record := range records {
+		table.AddRow(fmt.Sprintf("%03d", record.ID), record.Name, record.Status, cfg.style.Types(types[record.Name]))
+	}
+	return table.Write(cfg.out)
+}
+
+// showRegionalPokedex shows how much of a regional Pokédex the player has
+// completed, given either the region or the Pokédex name. It lists the
+// species seen there, or with missing the ones not caught yet.
+func (cfg *Config) showRegionalPokedex(regionName, dexName string, missing bool) error {
+	if dexName == "" {
+		region, err := pokeapi.GetRegion(cfg.cache, regionName)
+		if err != nil {
+			return fmt.Errorf("failed to fetch region info for '%s': %v", regionName, err)
+		}
+		if len(region.Pokedexes) == 0 {
+			return fmt.Errorf("the %s region has no Pokédex", region.Name)
+		}
+		dexName = region.Pokedexes[0].Name
+	}
+	pokedex, err := pokeapi.GetPokedex(cfg.cache, dexName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pokedex info for '%s': %v", dexName, err)
+	}
+
+	entries := slices.Clone(pokedex.PokemonEntries)
+	slices.SortFunc(entries, func(a, b pokeapi.PokedexEntry) int {
+		return cmp.Or(cmp.Compare(pokeapi.ResourceID(a.PokemonSpecies.URL), pokeapi.ResourceID(b.PokemonSpecies.URL)),
+			cmp.Compare(a.EntryNumber, b.EntryNumber))
+	})
+
+	records := []pokedexRecord{}
+	seen, caught := 0, 0
+	for _, entry := range entries {
+		name := entry.PokemonSpecies.Name
+		status := cfg.dexStatus(name)
+		if status != "missing" {
+			seen++
+		}
+		if status == "caught" {
+			caught++
+		}
+		if (missing && status == "caught") || (!missing && status == "missing") {
+			continue
+		}
+		records = append(records, pokedexRecord{ID: pokeapi.ResourceID(entry.PokemonSpecies.URL), Name: name, Status: status})
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	total := len(entries)
+	fmt.Fprintf(cfg.out, "%s Pokédex: %d/%d caught (%s), %d/%d seen (%s)\n",
+		pokedex.Name, caught, total, percent(caught, total), seen, total, percent(seen, total))
+	if len(records) == 0 {
+		if missing {
+			fmt.Fprintln(cfg.out, "You've caught them all!")
+		}
+		return nil
+	}
+	table := render.NewTable(cfg.style.Bold("#"), cfg.style.Bold("NAME"), cfg.style.Bold("STATUS"))
+	table.Indent = "  "
+	for _, record := range records {
+		table.AddRow(fmt.Sprintf("%03d", record.ID), record.Name, record.Status)
+	}
+	return table.Write(cfg.out)
+}
+
+// completePokedex offers region and Pokédex names after the flags that take
+// them.
+func completePokedex(cfg *Config, args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	switch args[len(args)-1] {
+	case "--region":
+		return cfg.knownNames(pokeapi.EndpointRegions)
+	case "--dex":
+		return cfg.knownNames(pokeapi.EndpointPokedexes)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// seedKantoDex caches a cut-down Kanto Pokédex with its entries out of
+// national order.
+func seedKantoDex(cfg *Config) {
+	cfg.cache.Add("https://pokeapi.co/api/v2/region/kanto", []byte(`{
+		"name": "kanto", "pokedexes": [{"name": "kanto", "url": "https://pokeapi.co/api/v2/pokedex/2/"}]
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokedex/kanto", []byte(`{
+		"name": "kanto",
+		"pokemon_entries": [
+			{"entry_number": 4, "pokemon_species": {"name": "rattata", "url": "https://pokeapi.co/api/v2/pokemon-species/19/"}},
+			{"entry_number": 1, "pokemon_species": {"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon-species/1/"}},
+			{"entry_number": 2, "pokemon_species": {"name": "pidgey", "url": "https://pokeapi.co/api/v2/pokemon-species/16/"}},
+			{"entry_number": 3, "pokemon_species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"}}
+		]
+	}`))
+}
+
+func TestExploreMarksSeen(t *testing.T) {
+	cfg := createTestConfig()
+	seedArea(cfg)
+
+	if err := commandExplore(cfg, []string{"route-1"}); err != nil {
+		t.Fatalf("explore returned an error: %v", err)
+	}
+	if status := cfg.dexStatus("pidgey"); status != "seen" {
+		t.Errorf("Expected pidgey to be seen after exploring, got %s", status)
+	}
+	if status := cfg.dexStatus("pikachu"); status != "missing" {
+		t.Errorf("Expected pikachu to be missing, got %s", status)
+	}
+}
+
+func TestExploreMarksFormsAsTheirSpecies(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/location-area/sinnoh-route-205", []byte(`{
+		"name": "sinnoh-route-205",
+		"pokemon_encounters": [
+			{"pokemon": {"name": "shellos-east", "url": "https://pokeapi.co/api/v2/pokemon/10039/"}, "version_details": [
+				{"version": {"name": "diamond", "url": ""}, "max_chance": 40, "encounter_details": [
+					{"min_level": 20, "max_level": 22, "chance": 40, "condition_values": [], "method": {"name": "walk", "url": ""}}
+				]}
+			]}
+		]
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/shellos-east", []byte(`{
+		"id": 10039, "name": "shellos-east",
+		"species": {"name": "shellos", "url": "https://pokeapi.co/api/v2/pokemon-species/422/"}
+	}`))
+
+	if err := commandExplore(cfg, []string{"sinnoh-route-205"}); err != nil {
+		t.Fatalf("explore returned an error: %v", err)
+	}
+	if err := commandPokedex(cfg, nil); err != nil {
+		t.Fatalf("pokedex returned an error: %v", err)
+	}
+	if entry, seen := cfg.Pokedex["shellos"]; !seen || entry.ID != 422 {
+		t.Errorf("Expected shellos #422 to be seen, got %+v", cfg.Pokedex)
+	}
+	if _, seen := cfg.Pokedex["shellos-east"]; seen {
+		t.Error("Expected the form to be recorded under its species")
+	}
+}
+
+func TestExploreLooksUpSpeciesOnlyForThePokedex(t *testing.T) {
+	cfg := createTestConfig()
+	seedArea(cfg)
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/rattata", []byte(`not json`))
+
+	if err := commandExplore(cfg, []string{"route-1"}); err != nil {
+		t.Fatalf("explore shouldn't look Pokémon up, got %v", err)
+	}
+	if err := commandPokedex(cfg, nil); err == nil || !strings.Contains(err.Error(), "rattata") {
+		t.Errorf("Expected the failed rattata lookup to be reported, got %v", err)
+	}
+	if entry := cfg.Pokedex["rattata"]; !entry.Pending {
+		t.Errorf("Expected rattata to stay pending until it can be looked up, got %+v", entry)
+	}
+	if entry := cfg.Pokedex["pidgey"]; entry.Pending || entry.ID != 25 {
+		t.Errorf("Expected pidgey to be resolved, got %+v", entry)
+	}
+}
+
+func TestRegionalPokedex(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	seedKantoDex(cfg)
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+	cfg.markSeen("pidgey", 16)
+
+	if err := commandPokedex(cfg, []string{"--region", "kanto"}); err != nil {
+		t.Fatalf("pokedex --region returned an error: %v", err)
+	}
+	want := "kanto Pokédex: 1/4 caught (25.0%), 2/4 seen (50.0%)\n" +
+		"  #    NAME     STATUS\n  016  pidgey   seen\n  025  pikachu  caught\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+
+	out.Reset()
+	if err := commandPokedex(cfg, []string{"--dex", "kanto", "--missing"}); err != nil {
+		t.Fatalf("pokedex --missing returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "001  bulbasaur  missing\n  016  pidgey     seen\n  019  rattata    missing\n") {
+		t.Errorf("Expected the uncaught Pokémon in national order, got %q", out.String())
+	}
+
+	if err := commandPokedex(cfg, []string{"--missing"}); err == nil {
+		t.Error("Expected an error for --missing without a Pokédex")
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func commandBerry(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("berry command requires a berry name as an argument")
+	}
+
+	berryName := commands[0]
+	berry, err := pokeapi.GetBerry(cfg.cache, berryName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch berry info for '%s': %v", berryName, err)
+	}
+	if handled, err := cfg.emit(berry); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Name: %s\n", berry.Name)
+	fmt.Fprintf(cfg.out, "Firmness: %s\n", berry.Firmness.Name)
+	fmt.Fprintf(cfg.out, "Growth time: %d hours per stage\n", berry.GrowthTime)
+	fmt.Fprintf(cfg.out, "Max harvest: %d\n", berry.MaxHarvest)
+	fmt.Fprintf(cfg.out, "Size: %d mm\n", berry.Size)
+	fmt.Fprintf(cfg.out, "Natural Gift: %s (power %d)\n", berry.NaturalGiftType.Name, berry.NaturalGiftPower)
+	fmt.Fprintln(cfg.out, "Flavors:")
+	for _, flavor := range berry.Flavors {
+		fmt.Fprintf(cfg.out, "  -%s: %d\n", flavor.Flavor.Name, flavor.Potency)
+	}
+	return nil
+}
+
+func commandNature(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("nature command requires a nature name as an argument")
+	}
+
+	natureName := commands[0]
+	nature, err := pokeapi.GetNature(cfg.cache, natureName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nature info for '%s': %v", natureName, err)
+	}
+	if handled, err := cfg.emit(nature); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Name: %s\n", nature.Name)
+	if nature.IncreasedStat == nil || nature.DecreasedStat == nil {
+		fmt.Fprintln(cfg.out, "This nature is neutral and doesn't affect stats.")
+	} else {
+		fmt.Fprintf(cfg.out, "Boosts: %s (+10%%)\n", nature.IncreasedStat.Name)
+		fmt.Fprintf(cfg.out, "Hinders: %s (-10%%)\n", nature.DecreasedStat.Name)
+	}
+	if nature.LikesFlavor != nil && nature.HatesFlavor != nil {
+		fmt.Fprintf(cfg.out, "Likes %s food, hates %s food.\n", nature.LikesFlavor.Name, nature.HatesFlavor.Name)
+	}
+	return nil
+}
+
+func commandCharacteristic(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("characteristic command requires a characteristic ID as an argument")
+	}
+
+	id, err := strconv.Atoi(commands[0])
+	if err != nil {
+		return fmt.Errorf("characteristic ID must be a number, got '%s'", commands[0])
+	}
+	characteristic, err := pokeapi.GetCharacteristic(cfg.cache, id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch characteristic %d: %v", id, err)
+	}
+	if handled, err := cfg.emit(characteristic); handled {
+		return err
+	}
+
+	values := make([]string, len(characteristic.PossibleValues))
+	for i, value := range characteristic.PossibleValues {
+		values[i] = strconv.Itoa(value)
+	}
+	fmt.Fprintf(cfg.out, "ID: %d\n", characteristic.ID)
+	fmt.Fprintf(cfg.out, "Description: %s\n", characteristic.Description())
+	fmt.Fprintf(cfg.out, "Highest IV: %s, one of %s\n", characteristic.HighestStat.Name, strings.Join(values, ", "))
+	return nil
+}
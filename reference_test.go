@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCommandBerryAndNatureRequireName(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandBerry(cfg, nil); err == nil {
+		t.Error("Expected an error when no berry name is given")
+	}
+	if err := commandNature(cfg, nil); err == nil {
+		t.Error("Expected an error when no nature name is given")
+	}
+}
+
+func TestCommandNatureCached(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/nature/modest", []byte(`{
+		"id": 15,
+		"name": "modest",
+		"increased_stat": {"name": "special-attack", "url": ""},
+		"decreased_stat": {"name": "attack", "url": ""},
+		"likes_flavor": {"name": "dry", "url": ""},
+		"hates_flavor": {"name": "spicy", "url": ""}
+	}`))
+
+	if err := commandNature(cfg, []string{"modest"}); err != nil {
+		t.Errorf("nature command returned an error: %v", err)
+	}
+}
+
+func TestCommandCharacteristicCached(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.cache.Add("https://pokeapi.co/api/v2/characteristic/7", []byte(`{
+		"id": 7,
+		"gene_modulo": 1,
+		"possible_values": [1, 6, 11, 16, 21, 26, 31],
+		"highest_stat": {"name": "hp", "url": ""},
+		"descriptions": [{"description": "Takes plenty of siestas", "language": {"name": "en", "url": ""}}]
+	}`))
+
+	if err := commandCharacteristic(cfg, []string{"7"}); err != nil {
+		t.Fatalf("characteristic command returned an error: %v", err)
+	}
+	want := "ID: 7\nDescription: Takes plenty of siestas\nHighest IV: hp, one of 1, 6, 11, 16, 21, 26, 31\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+
+	if err := commandCharacteristic(cfg, []string{"plenty"}); err == nil {
+		t.Error("Expected an error for a characteristic ID that isn't a number")
+	}
+}
+
+func TestCommandBerryCached(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/berry/oran", []byte(`{
+		"id": 7,
+		"name": "oran",
+		"growth_time": 4,
+		"firmness": {"name": "super-hard", "url": ""},
+		"flavors": [{"potency": 10, "flavor": {"name": "spicy", "url": ""}}]
+	}`))
+
+	if err := commandBerry(cfg, []string{"oran"}); err != nil {
+		t.Errorf("berry command returned an error: %v", err)
+	}
+}
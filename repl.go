@@ -1,157 +1,310 @@
 package main
 
 import (
+	"errors"
 	"strings"
 	"fmt"
-	"os"
-	"math/rand"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
 	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
 )
 
 type cliCommand struct {
 	name        string
 	description string
 	callback    func(*Config, []string) error
+	complete    func(*Config, []string) []string // Optional completion given the preceding arguments
+	rawArgs     bool                             // Pass arguments as typed rather than lowercased, for file paths and names
 }
 
+// errExit is returned by the exit command to end the REPL session.
+var errExit = errors.New("exit requested")
+
 func commandExit(cfg *Config, commands []string) error {
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil
+	fmt.Fprintln(cfg.out, "Closing the Pokedex... Goodbye!")
+	return errExit
 }
 
 func commandMap(cfg *Config, commands []string) error {
-    NextLocURL, prevLocURL, locs, err := pokeapi.GetLocationAreas(cfg.cache, cfg.NextLocationURL)
+	args, flags, err := parseArgs(commands)
 	if err != nil {
-		return fmt.Errorf("failed to fetch location areas: %v", err)
+		return err
 	}
 
-	for _, loc := range locs.Results {
-		fmt.Printf("%s\n", loc.Name)
+	if value, set := flags["limit"]; set {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return fmt.Errorf("--limit must be a positive number, got '%s'", value)
+		}
+		cfg.LocationLimit = limit
 	}
-	cfg.NextLocationURL = NextLocURL
-	cfg.PreviousLocationURL = prevLocURL
+	limit := cfg.locationLimit()
 
-	return nil
+	offset := 0
+	switch {
+	case flags["page"] != "":
+		page, err := strconv.Atoi(flags["page"])
+		if err != nil || page < 1 {
+			return fmt.Errorf("--page must be a positive number, got '%s'", flags["page"])
+		}
+		offset = (page - 1) * limit
+	case len(args) > 0 && args[0] == "first":
+		offset = 0
+	case len(args) > 0 && args[0] == "last":
+		if cfg.LocationCount == 0 {
+			// Fetch a single entry just to learn how many areas exist.
+			locs, err := pokeapi.GetResourceList(cfg.cache, pokeapi.EndpointLocationAreas, 0, 1)
+			if err != nil {
+				return fmt.Errorf("failed to fetch location areas: %v", err)
+			}
+			cfg.LocationCount = locs.Count
+		}
+		offset = max(0, (cfg.LocationCount-1)/limit*limit)
+	case len(args) > 0:
+		return fmt.Errorf("unknown map argument '%s', expected first or last", args[0])
+	case cfg.LocationPageShown:
+		offset = cfg.LocationOffset + limit
+	}
+
+	if cfg.LocationCount > 0 && offset >= cfg.LocationCount {
+		fmt.Fprintln(cfg.out, "you're on the last page")
+		return nil
+	}
+	return showLocationPage(cfg, offset)
 }
 
 func commandMapb(cfg *Config, commands []string) error {
-	if cfg.PreviousLocationURL == nil {
-		fmt.Println("you're on the first page")
+	if !cfg.LocationPageShown || cfg.LocationOffset == 0 {
+		fmt.Fprintln(cfg.out, "you're on the first page")
 		return nil
-	} else {
-		// Fetch the previous page of locations using cfg.PreviousLocationURL
-		// Update cfg.NextLocationURL and cfg.PreviousLocationURL accordingly
-		NextURL, prevURL, locs, err := pokeapi.GetLocationAreas(cfg.cache, cfg.PreviousLocationURL)
-		if err != nil {
-			return fmt.Errorf("failed to fetch previous location areas: %v", err)
-		}
+	}
+	return showLocationPage(cfg, max(0, cfg.LocationOffset-cfg.locationLimit()))
+}
 
-		for _, loc := range locs.Results {
-			fmt.Printf("%s\n", loc.Name)
+// showLocationPage prints the location areas starting at offset and records
+// the page as the current one.
+func showLocationPage(cfg *Config, offset int) error {
+	limit := cfg.locationLimit()
+	locs, err := pokeapi.GetResourceList(cfg.cache, pokeapi.EndpointLocationAreas, offset, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch location areas: %v", err)
+	}
+	if len(locs.Results) == 0 {
+		if handled, err := cfg.emit([]pokeapi.NamedAPIResource{}); handled {
+			return err
 		}
-		cfg.NextLocationURL = NextURL
-		cfg.PreviousLocationURL = prevURL
+		fmt.Fprintln(cfg.out, "There are no location areas on that page.")
+		return nil
+	}
+
+	cfg.LocationOffset = offset
+	cfg.LocationCount = locs.Count
+	cfg.LocationPageShown = true
+	if handled, err := cfg.emit(locs.Results); handled {
+		return err
+	}
+
+	for _, loc := range locs.Results {
+		fmt.Fprintf(cfg.out, "%s\n", loc.Name)
 	}
+
+	totalPages := (locs.Count + limit - 1) / limit
+	fmt.Fprintf(cfg.out, "page %d of %d\n", offset/limit+1, totalPages)
 	return nil
 }
 
-func commandExplore(cfg *Config, commands []string) error {
-	if len(commands) < 1 {
-		return fmt.Errorf("explore command requires a location area name as an argument")
+// locationLimit returns the configured page size for map, falling back to
+// the API default when unset.
+func (cfg *Config) locationLimit() int {
+	if cfg.LocationLimit <= 0 {
+		return pokeapi.DefaultPageSize
 	}
-	
-	locationAreaName := commands[0]
-	fmt.Printf("Exploring location area: %s\n", locationAreaName)
-	pokemons, err := pokeapi.GetLocationPokemons(cfg.cache, locationAreaName)
+	return cfg.LocationLimit
+}
+
+func commandExplore(cfg *Config, commands []string) error {
+	args, flags, err := parseArgs(commands)
 	if err != nil {
-		return fmt.Errorf("failed to fetch Pokémon for location area '%s': %v", locationAreaName, err)
+		return err
 	}
-	fmt.Printf("Found Pokémon in %s:\n", locationAreaName)
-	for _, pokemon := range pokemons {
-		fmt.Printf(" - %s\n", pokemon)
+	if len(args) < 1 {
+		return fmt.Errorf("explore command requires a location area name as an argument")
 	}
-	return nil
-}
 
-func commandCatch(cfg *Config, commands []string) error {
-	if len(commands) < 1 {
-		return fmt.Errorf("catch command requires a Pokémon name as an argument")
+	version := flags["version"]
+	area, locationAreaName, err := cfg.lookupArea(args[0])
+	if err != nil {
+		return err
 	}
+	cfg.enterArea(locationAreaName)
 
-	pokemonName := commands[0]
-	
-	// Check if Pokemon is already caught
-	if _, exists := cfg.PokemonCaught[pokemonName]; exists {
-		fmt.Printf("You already caught %s!\n", pokemonName)
-		return nil
+	encounters := area.Encounters(version)
+	if encounters == nil {
+		encounters = []pokeapi.EncounterSummary{}
 	}
-	
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
-	pokeInfo, err := pokeapi.GetPokemonInfo(cfg.cache, pokemonName)
-	if err != nil {
-		return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", pokemonName, err)
+	cfg.markEncountersSeen(area, encounters)
+	if handled, err := cfg.emit(encounters); handled {
+		return err
 	}
 
-	baseExperience := pokeInfo.BaseExperience
-	if baseExperience > 100 {
-		fmt.Printf("%s appears to be strong (base experience: %d)!\n", pokemonName, baseExperience)
-	} else {
-		fmt.Printf("%s appears to be manageable (base experience: %d).\n", pokemonName, baseExperience)
+	fmt.Fprintf(cfg.out, "Exploring location area: %s\n", locationAreaName)
+	if area.Location.Name != "" {
+		fmt.Fprintf(cfg.out, "Part of location: %s\n", area.Location.Name)
 	}
-	
-	// Attempt to catch a Pokémon with a 50% success rate
-	if rand.Intn(2) == 0 {
-		fmt.Printf("%s was caught!\n", pokemonName)
-		fmt.Println("You may now inspect it with the inspect command.")
-		cfg.PokemonCaught[pokemonName] = *pokeInfo
-	} else {
-		fmt.Printf("%s escaped the Pokeball!\n", pokemonName)
+	if len(encounters) == 0 {
+		if version != "" {
+			fmt.Fprintf(cfg.out, "No Pokémon found in %s for version %s.\n", locationAreaName, version)
+		} else {
+			fmt.Fprintf(cfg.out, "No Pokémon found in %s.\n", locationAreaName)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(cfg.out, "Found Pokémon in %s:\n", locationAreaName)
+	printEncounterTable(cfg.out, encounters, "POKEMON", func(e pokeapi.EncounterSummary) string { return e.Pokemon })
+
+	rates := area.MethodRates(version)
+	if len(rates) > 0 {
+		fmt.Fprintln(cfg.out, "Encounter method rates:")
+		for _, rate := range rates {
+			fmt.Fprintf(cfg.out, " - %s (%s): %d%%\n", rate.Method, rate.Version, rate.Rate)
+		}
 	}
-	
 	return nil
 }
 
-func commandPokedex(cfg *Config, commands []string) error {
-	if len(cfg.PokemonCaught) == 0 {
-		fmt.Println("You haven't caught any Pokémon yet!")
-		return nil
+// printEncounterTable writes encounter summaries as an aligned table. The
+// first column is chosen by the caller so the same layout serves both
+// "who lives here" and "where does it live" listings.
+func printEncounterTable(w io.Writer, encounters []pokeapi.EncounterSummary, firstHeader string, first func(pokeapi.EncounterSummary) string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tVERSION\tMETHOD\tLEVELS\tCHANCE\tCONDITIONS\n", firstHeader)
+	for _, e := range encounters {
+		levels := fmt.Sprintf("%d", e.MinLevel)
+		if e.MaxLevel != e.MinLevel {
+			levels = fmt.Sprintf("%d-%d", e.MinLevel, e.MaxLevel)
+		}
+		conditions := "-"
+		if len(e.Conditions) > 0 {
+			conditions = strings.Join(e.Conditions, ", ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d%%\t%s\n", first(e), e.Version, e.Method, levels, e.Chance, conditions)
 	}
-	
-	fmt.Println("Your Pokedex:")
-	for name := range cfg.PokemonCaught {
-		fmt.Printf(" - %s\n", name)
+	tw.Flush()
+}
+
+func commandCatch(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("catch command requires a Pokémon name as an argument")
 	}
-	return nil
+
+	ball := "poke-ball"
+	if len(commands) > 1 {
+		ball = commands[1]
+	}
+	return throwBall(cfg, commands[0], ball)
 }
 
+// statBarWidth is the number of cells in the stat bars shown by inspect.
+const statBarWidth = 30
+
 func commandInspect(cfg *Config, commands []string) error {
-	if len(commands) < 1 {
+	args, flags, err := parseArgs(commands, "sprite", "shiny", "back", "female")
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
 		return fmt.Errorf("inspect command requires a Pokémon name as an argument")
 	}
 
-	pokemonName := commands[0]
+	pokemonName := args[0]
 	
 	// Check if the Pokemon has been caught
-	pokemon, exists := cfg.PokemonCaught[pokemonName]
-	if !exists {
-		fmt.Println("you have not caught that pokemon")
-		return nil
+	caught, err := cfg.findPokemon(pokemonName)
+	if errors.Is(err, errPokemonNotCaught) {
+		corrected, hint := cfg.correctName(pokemonName, cfg.caughtNames())
+		if corrected != "" {
+			cfg.notef("Assuming you meant %s.\n", corrected)
+			retry := slices.Clone(commands)
+			retry[slices.Index(retry, pokemonName)] = corrected
+			return commandInspect(cfg, retry)
+		}
+		return fmt.Errorf("you have not caught that pokemon%s", hint)
+	}
+	if err != nil {
+		return err
 	}
+	pokemon := caught.Info
 	
+	if handled, err := cfg.emit(newPokemonRecord(caught)); handled {
+		return err
+	}
+
 	// Display Pokemon information
-	fmt.Printf("Name: %s\n", pokemon.Name)
-	fmt.Printf("Height: %d\n", pokemon.Height)
-	fmt.Printf("Weight: %d\n", pokemon.Weight)
-	fmt.Println("Stats:")
+	fmt.Fprintf(cfg.out, "ID: %s\n", caught.ID)
+	fmt.Fprintf(cfg.out, "Name: %s\n", pokemon.Name)
+	if caught.Nickname != "" {
+		fmt.Fprintf(cfg.out, "Nickname: %s\n", caught.Nickname)
+	}
+	fmt.Fprintf(cfg.out, "Level: %d\n", caught.Level)
+	fmt.Fprintf(cfg.out, "Experience: %d\n", caught.Experience)
+	fmt.Fprintf(cfg.out, "Height: %d\n", pokemon.Height)
+	fmt.Fprintf(cfg.out, "Weight: %d\n", pokemon.Weight)
+	fmt.Fprintf(cfg.out, "Types: %s\n", cfg.style.Types(typeNames(pokemon)))
+	if caught.Nature != "" {
+		fmt.Fprintf(cfg.out, "Nature: %s\n", caught.natureSummary())
+	}
+	if caught.Gender != "" {
+		fmt.Fprintf(cfg.out, "Gender: %s\n", caught.Gender)
+	}
+	if caught.Shiny {
+		fmt.Fprintln(cfg.out, "Shiny: yes")
+	}
+	if caught.OriginalTrainer != "" {
+		fmt.Fprintf(cfg.out, "Original trainer: %s\n", caught.OriginalTrainer)
+	}
+	if caught.Location != "" {
+		fmt.Fprintf(cfg.out, "Caught: %s in %s\n", caught.CaughtAt.Format(time.DateTime), caught.Location)
+	} else {
+		fmt.Fprintf(cfg.out, "Caught: %s\n", caught.CaughtAt.Format(time.DateTime))
+	}
+	fmt.Fprintln(cfg.out, "Stats:")
+	table := render.NewTable()
+	if len(pokemon.Stats) > 0 {
+		table = render.NewTable(cfg.style.Bold("STAT"), cfg.style.Bold("BASE"), "",
+			cfg.style.Bold(fmt.Sprintf("LV %d", caught.Level)), cfg.style.Bold("IV"), cfg.style.Bold("EV"))
+	}
+	table.Indent = "  "
+	// Base stats with their bars, then the stats at the Pokémon's level and
+	// what they come from.
+	stats := caught.Stats()
 	for _, stat := range pokemon.Stats {
-		fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+		name := stat.Stat.Name
+		table.AddRow(name, fmt.Sprintf("%3d", stat.BaseStat), cfg.style.StatBar(stat.BaseStat, statBarWidth),
+			fmt.Sprint(stats.Get(name)), fmt.Sprint(caught.IVs.Get(name)), fmt.Sprint(caught.EVs.Get(name)))
 	}
-	fmt.Println("Types:")
-	for _, pokemonType := range pokemon.Types {
-		fmt.Printf("  - %s\n", pokemonType.Type.Name)
+	if err := table.Write(cfg.out); err != nil {
+		return err
+	}
+
+	variant := pokeapi.SpriteVariant{
+		Back:   flags["back"] != "",
+		Shiny:  flags["shiny"] != "",
+		Female: flags["female"] != "",
+	}
+	if flags["sprite"] != "" || variant != (pokeapi.SpriteVariant{}) {
+		// Draw the Pokémon as it is unless another look was asked for.
+		if flags["sprite"] != "" {
+			variant.Shiny = variant.Shiny || caught.Shiny
+			variant.Female = variant.Female || caught.Gender == "female"
+		}
+		return showSprite(cfg, pokemon, variant)
 	}
-	
 	return nil
 }
 
@@ -163,45 +316,255 @@ var commands_map = map[string]cliCommand{
 	},
 	"map": {
 		name:        "map",
-		description: "Display a list of all Pokémon locations in the Pokedex. (grouped in batches of 20)",
+		description: "Display the next page of Pokémon location areas (20 per page by default). Accepts first, last, --page <n> and --limit <n>.",
 		callback:    commandMap,
+		complete:    completeWords([]string{"first", "last"}),
 	},
 	"mapb": {
 		name:        "mapb",
-		description: "Display the previous page of all Pokémon locations in the Pokedex. (grouped in batches of 20)",
+		description: "Display the previous page of Pokémon location areas.",
 		callback:    commandMapb,
 	},
+	"regions": {
+		name:        "regions",
+		description: "Display a list of all regions.",
+		callback:    commandRegions,
+	},
+	"region": {
+		name:        "region",
+		description: "Display the locations within a region. Requires a region name as an argument.",
+		callback:    commandRegion,
+		complete:    completeNames(pokeapi.EndpointRegions),
+	},
+	"location": {
+		name:        "location",
+		description: "Display the areas within a location. Requires a location name as an argument.",
+		callback:    commandLocation,
+		complete:    completeNames(pokeapi.EndpointLocations),
+	},
+	"list": {
+		name:        "list",
+		description: "List resources such as pokemon, items, moves or types. Requires a resource name as an argument; add --limit <n> to control how many are shown.",
+		callback:    commandList,
+		complete:    completeWords(slices.Sorted(maps.Keys(listEndpoints))),
+	},
 	"explore": {
 		name:        "explore",
-		description: "Explore a specific location area by name, listing the Pokémon that can be found there. Requires a location area name as an argument.",
+		description: "Go to a location area and list the Pokémon that can be found there and how. Requires a location area name as an argument; add --version <game> to filter by game version.",
 		callback:    commandExplore,
+		complete:    completeNames(pokeapi.EndpointLocationAreas),
+	},
+	"goto": {
+		name:        "goto",
+		description: "Go to a location area without listing its Pokémon, or show where you are when no name is given.",
+		callback:    commandGoto,
+		complete:    completeNames(pokeapi.EndpointLocationAreas),
+	},
+	"encounter": {
+		name:        "encounter",
+		description: "Look for a wild Pokémon in the current location area. Add --version <game> to meet Pokémon from that game and --method <method> to look some other way than walking.",
+		callback:    commandEncounter,
+	},
+	"where": {
+		name:        "where",
+		description: "List the location areas where a Pokémon can be found. Requires a Pokémon name as an argument; add --version <game> to filter by game version.",
+		callback:    commandWhere,
+		complete:    completeNames(pokeapi.EndpointPokemon),
 	},
 	"catch": {
 		name:        "catch",
-		description: "Attempt to catch a specific Pokémon by name. Requires a Pokémon name as an argument.",
+		description: "Attempt to catch the wild Pokémon you encountered. Requires its name as an argument, optionally followed by the ball to throw (default poke-ball).",
 		callback:    commandCatch,
+		complete:    completeCatch,
+	},
+	"bag": {
+		name:        "bag",
+		description: "View the items in your bag.",
+		callback:    commandBag,
+	},
+	"item": {
+		name:        "item",
+		description: "View detailed information about an item. Requires an item name as an argument.",
+		callback:    commandItem,
+		complete:    completeNames(pokeapi.EndpointItems),
+	},
+	"berry": {
+		name:        "berry",
+		description: "View a berry's firmness, flavors and growth time. Requires a berry name as an argument.",
+		callback:    commandBerry,
+		complete:    completeNames(pokeapi.EndpointBerries),
+	},
+	"nature": {
+		name:        "nature",
+		description: "View the stat boosted and hindered by a nature. Requires a nature name as an argument.",
+		callback:    commandNature,
+		complete:    completeNames(pokeapi.EndpointNatures),
+	},
+	"characteristic": {
+		name:        "characteristic",
+		description: "View a characteristic, the hint at a Pokémon's highest IV. Requires a characteristic ID (1-30) as an argument.",
+		callback:    commandCharacteristic,
+	},
+	"use": {
+		name:        "use",
+		description: "Use an item from your bag. Poké Balls are thrown at a wild Pokémon given as the target (e.g. use great-ball pikachu); potions heal your Pokémon in battle.",
+		callback:    commandUse,
+		complete:    completeUse,
+	},
+	"shop": {
+		name:        "shop",
+		description: "Visit the Poké Mart to see the Poké Balls and potions for sale.",
+		callback:    commandShop,
+	},
+	"buy": {
+		name:        "buy",
+		description: "Buy an item from the Poké Mart with your money. Requires an item name, optionally followed by a quantity.",
+		callback:    commandBuy,
+		complete:    completeWords(shopStock),
+	},
+	"seed": {
+		name:        "seed",
+		description: "Show the random seed of this session, or pass a number to reseed it and replay catches exactly.",
+		callback:    commandSeed,
+	},
+	"fight": {
+		name:        "fight",
+		description: "Attack the wild Pokémon with one of your lead Pokémon's moves, by name or number. Lists the moves when none is given.",
+		callback:    commandFight,
+		complete:    completeFight,
+	},
+	"run": {
+		name:        "run",
+		description: "Try to flee from the wild Pokémon.",
+		callback:    commandRun,
+	},
+	"lead": {
+		name:        "lead",
+		description: "Move a caught Pokémon to the first party slot so it battles first, or show the current lead when no name is given.",
+		callback:    commandLead,
+		complete:    completeCaught,
+	},
+	"profile": {
+		name:        "profile",
+		description: "Display your trainer profile. Use profile name <name> to change your name.",
+		callback:    commandProfile,
+		complete:    completeWords([]string{"name"}),
+		rawArgs:     true,
+	},
+	"achievements": {
+		name:        "achievements",
+		description: "Display your achievements and your progress towards them.",
+		callback:    commandAchievements,
+	},
+	"badges": {
+		name:        "badges",
+		description: "Display the Kanto gym badges. A badge is earned by raising a Pokémon of the gym's type to the level of the leader's strongest Pokémon.",
+		callback:    commandBadges,
+	},
+	"party": {
+		name:        "party",
+		description: "Display the Pokémon in your party.",
+		callback:    commandParty,
+	},
+	"box": {
+		name:        "box",
+		description: "Display the Pokémon stored in your PC boxes. Accepts a box number to show only that box.",
+		callback:    commandBox,
+	},
+	"deposit": {
+		name:        "deposit",
+		description: "Move a Pokémon from your party to a PC box. Accepts a box number after the Pokémon.",
+		callback:    commandDeposit,
+		complete:    completeCaught,
+	},
+	"withdraw": {
+		name:        "withdraw",
+		description: "Move a Pokémon from a PC box to your party.",
+		callback:    commandWithdraw,
+		complete:    completeCaught,
+	},
+	"swap": {
+		name:        "swap",
+		description: "Swap the places of two Pokémon, given by party slot, ID or name.",
+		callback:    commandSwap,
+		complete:    completeSwap,
+	},
+	"release": {
+		name:        "release",
+		description: "Release a caught Pokémon into the wild. Add --yes to confirm.",
+		callback:    commandRelease,
+		complete:    completeCaught,
+	},
+	"trade": {
+		name:        "trade",
+		description: "Trade Pokémon with other players: trade export <pokemon> <file> sends one away in a signed trade file, trade import <file> receives one. Trades are only accepted from partners added with trade trust <name> <key>, whose key trade key shows.",
+		callback:    commandTrade,
+		complete:    completeTrade,
+		rawArgs:     true,
+	},
+	"nickname": {
+		name:        "nickname",
+		description: "Give a caught Pokémon a nickname, or remove it when no nickname is given.",
+		callback:    commandNickname,
+		complete:    completeCaught,
+		rawArgs:     true,
+	},
+	"set": {
+		name:        "set",
+		description: "Change a setting. Usage: set autocorrect on|off, set output text|json|yaml|csv",
+		callback:    commandSet,
+		complete:    completeSet,
 	},
 	"pokedex": {
 		name:        "pokedex",
-		description: "View all the Pokémon you've caught.",
+		description: "View the Pokémon you've seen and caught. Add --region <name> or --dex <name> for your progress in a regional Pokédex, and --missing to list the Pokémon still to catch there.",
 		callback:    commandPokedex,
+		complete:    completePokedex,
 	},
 	"inspect": {
 		name:        "inspect",
-		description: "View detailed information about a caught Pokémon. Requires a Pokémon name as an argument.",
+		description: "View detailed information about a caught Pokémon. Requires a Pokémon name as an argument; add --sprite to draw it, with --shiny, --back or --female for other sprites.",
 		callback:    commandInspect,
+		complete:    completeCaught,
 	},
 }
 
 
 func commandHelp(cfg *Config, commands []string) error {
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("Usage:")
-	fmt.Println()
-	fmt.Println(" - help: Display a help message")
+	fmt.Fprintln(cfg.out, "Welcome to the Pokedex!")
+	fmt.Fprintln(cfg.out, "Usage:")
+	fmt.Fprintln(cfg.out)
+	fmt.Fprintln(cfg.out, " - help: Display a help message")
 	for _, cmd := range commands_map {
-		fmt.Printf(" - %s: %s\n", cmd.name, cmd.description)
+		fmt.Fprintf(cfg.out, " - %s: %s\n", cmd.name, cmd.description)
+	}
+	return nil
+}
+
+var errUnknownCommand = errors.New("unknown command")
+
+// executeCommand runs a single line of REPL input. Blank lines are ignored.
+func executeCommand(cfg *Config, input string) error {
+	command := cleanInput(input)
+	if len(command) == 0 {
+		return nil
+	}
+	if command[0] == "help" {
+		return commandHelp(cfg, command[1:])
+	}
+	cmd, exists := commands_map[command[0]]
+	if !exists {
+		return fmt.Errorf("%w '%s'", errUnknownCommand, command[0])
+	}
+	args := command[1:]
+	if cmd.rawArgs {
+		args = strings.Fields(input)[1:]
+	}
+	if err := cmd.callback(cfg, args); err != nil {
+		return err
 	}
+	cfg.checkAchievements()
+	cfg.checkBadges()
 	return nil
 }
 
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+)
+
+// reseed restarts the session's random numbers from seed, so two sessions
+// given the same seed and the same commands play out identically.
+func (cfg *Config) reseed(seed uint64) {
+	cfg.Seed = seed
+	cfg.rngSource = rand.NewPCG(seed, seed)
+	cfg.rng = rand.New(cfg.rngSource)
+	cfg.rngResumed = false
+}
+
+func commandSeed(cfg *Config, commands []string) error {
+	if len(commands) == 0 {
+		if cfg.rngResumed {
+			cfg.notef("Random numbers continue where your saved game left off; they were first seeded with %d.\n", cfg.Seed)
+			cfg.notef("Pass a seed to start a sequence you can replay.\n")
+			return nil
+		}
+		cfg.notef("Current seed: %d\n", cfg.Seed)
+		return nil
+	}
+
+	seed, err := strconv.ParseUint(commands[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("seed must be a non-negative whole number, got '%s'", commands[0])
+	}
+	cfg.reseed(seed)
+	cfg.notef("Random numbers now come from seed %d.\n", seed)
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// catchOutcomes throws every poke-ball in the bag at a hard-to-catch
+// Pokémon and returns the log of what happened.
+func catchOutcomes(t *testing.T, seed uint64) string {
+	t.Helper()
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	cfg.reseed(seed)
+	seedPokemon(cfg, "mewtwo", 306)
+	seedSpecies(cfg, "mewtwo", 3)
+
+	for cfg.Bag["poke-ball"] > 0 {
+		meetWild(cfg, "mewtwo")
+		if err := commandCatch(cfg, []string{"mewtwo"}); err != nil {
+			t.Fatalf("catch returned an error: %v", err)
+		}
+	}
+	return out.String()
+}
+
+func TestCatchIsReproducibleWithSeed(t *testing.T) {
+	first := catchOutcomes(t, 42)
+	if second := catchOutcomes(t, 42); second != first {
+		t.Errorf("Expected identical sessions for the same seed:\n%s\n---\n%s", first, second)
+	}
+	if other := catchOutcomes(t, 7); other == first {
+		t.Error("Expected a different seed to produce a different session")
+	}
+}
+
+func TestCommandSeed(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandSeed(cfg, []string{"1234"}); err != nil {
+		t.Fatalf("seed returned an error: %v", err)
+	}
+	if cfg.Seed != 1234 {
+		t.Errorf("Expected seed 1234, got %d", cfg.Seed)
+	}
+	want := createTestConfig()
+	want.reseed(1234)
+	if got, expected := cfg.rng.Uint64(), want.rng.Uint64(); got != expected {
+		t.Errorf("Expected the reseeded sequence to start at %d, got %d", expected, got)
+	}
+
+	if err := commandSeed(cfg, []string{"-1"}); err == nil {
+		t.Error("Expected an error for a negative seed")
+	}
+	if err := commandSeed(cfg, nil); err != nil {
+		t.Errorf("seed without arguments returned an error: %v", err)
+	}
+}
+
+func TestSaveGameContinuesRandomSequence(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+	cfg.reseed(99)
+	cfg.rng.Uint64()
+
+	if err := cfg.saveGame(); err != nil {
+		t.Fatalf("saveGame returned an error: %v", err)
+	}
+	next := cfg.rng.Uint64()
+
+	restored := createTestConfig()
+	restored.SavePath = cfg.SavePath
+	if err := restored.loadGame(); err != nil {
+		t.Fatalf("loadGame returned an error: %v", err)
+	}
+	if restored.Seed != 99 {
+		t.Errorf("Expected seed 99 to be restored, got %d", restored.Seed)
+	}
+	if got := restored.rng.Uint64(); got != next {
+		t.Errorf("Expected the restored sequence to continue with %d, got %d", next, got)
+	}
+
+	var out bytes.Buffer
+	restored.out = &out
+	if err := commandSeed(restored, nil); err != nil {
+		t.Fatalf("seed returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "saved game") || strings.Contains(out.String(), "Current seed") {
+		t.Errorf("Expected seed to say the sequence was resumed from the save, got %q", out.String())
+	}
+	out.Reset()
+	if err := commandSeed(restored, []string{"99"}); err != nil {
+		t.Fatalf("seed 99 returned an error: %v", err)
+	}
+	if err := commandSeed(restored, nil); err != nil || !strings.Contains(out.String(), "Current seed: 99") {
+		t.Errorf("Expected reseeding to replace the resumed sequence, got %q (%v)", out.String(), err)
+	}
+}
+
+func TestPokemonIDsAreReproducibleWithSeed(t *testing.T) {
+	ids := func(seed uint64) []string {
+		cfg := createTestConfig()
+		cfg.reseed(seed)
+		return []string{cfg.newPokemonID(), cfg.newPokemonID()}
+	}
+	first := ids(42)
+	if second := ids(42); !slices.Equal(second, first) {
+		t.Errorf("Expected the same IDs for the same seed, got %v and %v", first, second)
+	}
+}
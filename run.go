@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/lineedit"
+	"github.com/OttScott/pokedexcli/internal/render"
+	"github.com/OttScott/pokedexcli/internal/term"
+)
+
+const (
+	prompt = "POKEDEX > "
+	// maxLineLength bounds a single line of non-interactive input.
+	maxLineLength = 1024 * 1024
+)
+
+// REPL reads commands and executes them against a Config.
+type REPL struct {
+	cfg         *Config
+	historyPath string
+}
+
+func newREPL(cfg *Config) *REPL {
+	return &REPL{cfg: cfg}
+}
+
+// Run reads commands from in until end of input or the exit command, then
+// saves the session. All command output is written to out. When in is a
+// terminal the user gets a prompt, with line editing where the terminal
+// supports raw mode, and failing commands are reported to out. Otherwise
+// commands are read line by line without prompts and the first failing
+// command stops the run and is returned.
+func (r *REPL) Run(in io.Reader, out io.Writer) error {
+	r.cfg.out = out
+	r.cfg.style = render.Detect(out)
+
+	var err error
+	if f, ok := in.(*os.File); ok && term.IsTerminal(f.Fd()) {
+		err = r.runInteractive(f, out)
+	} else {
+		err = r.runScript(in)
+	}
+
+	if saveErr := r.cfg.saveGame(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+func (r *REPL) runInteractive(in *os.File, out io.Writer) error {
+	editor := lineedit.New(in, out)
+	editor.Complete = r.cfg.complete
+	if r.historyPath != "" {
+		if err := editor.LoadHistory(r.historyPath); err != nil {
+			fmt.Fprintf(out, "Warning: %v\n", err)
+		}
+	}
+
+	for {
+		input, err := editor.ReadLine(prompt)
+		if errors.Is(err, lineedit.ErrInterrupted) {
+			continue
+		}
+		if err == io.EOF {
+			// Ctrl-D behaves exactly like the exit command.
+			commandExit(r.cfg, nil)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %v", err)
+		}
+
+		if err := editor.AddHistory(input); err != nil {
+			fmt.Fprintf(out, "Warning: %v\n", err)
+		}
+		err = executeCommand(r.cfg, input)
+		switch {
+		case errors.Is(err, errExit):
+			return nil
+		case errors.Is(err, errUnknownCommand):
+			fmt.Fprintln(out, "Unknown command.")
+		case err != nil:
+			fmt.Fprintf(out, "Error executing command '%s': %v\n", cleanInput(input), err)
+		}
+	}
+}
+
+// runScript executes commands from in, one per line. Blank lines and lines
+// starting with # are skipped.
+func (r *REPL) runScript(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		err := executeCommand(r.cfg, line)
+		if errors.Is(err, errExit) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %v", lineNumber, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read commands: %v", err)
+	}
+	return nil
+}
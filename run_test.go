@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingReader returns some input followed by a read error.
+type failingReader struct {
+	data io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, errors.New("disk on fire")
+	}
+	return n, err
+}
+
+func TestRunStopsAtEOF(t *testing.T) {
+	cfg := createTestConfig()
+	script := "# enable typo correction\n\nset autocorrect on\n  bag  "
+
+	if err := newREPL(cfg).Run(strings.NewReader(script), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if !cfg.AutoCorrect {
+		t.Error("Expected the script to enable autocorrect")
+	}
+}
+
+func TestRunStopsOnFailure(t *testing.T) {
+	cfg := createTestConfig()
+	script := "bag\nset autocorrect maybe\nset autocorrect on\n"
+
+	err := newREPL(cfg).Run(strings.NewReader(script), &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("Expected the failing command to stop the run")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected the error to name the failing line, got %v", err)
+	}
+	if cfg.AutoCorrect {
+		t.Error("Commands after the failure should not run")
+	}
+}
+
+func TestRunStopsAtExit(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := newREPL(cfg).Run(strings.NewReader("exit\nset autocorrect on\n"), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if cfg.AutoCorrect {
+		t.Error("Commands after exit should not run")
+	}
+}
+
+func TestRunReportsReadErrors(t *testing.T) {
+	cfg := createTestConfig()
+	in := &failingReader{data: strings.NewReader("bag\n")}
+
+	err := newREPL(cfg).Run(in, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "disk on fire") {
+		t.Errorf("Expected the read error to be returned, got %v", err)
+	}
+}
+
+func TestRunLongLines(t *testing.T) {
+	cfg := createTestConfig()
+	line := "set autocorrect on" + strings.Repeat(" ", 200*1024) + "\n"
+
+	if err := newREPL(cfg).Run(strings.NewReader(line), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Run returned an error for a long line: %v", err)
+	}
+	if !cfg.AutoCorrect {
+		t.Error("Expected the long line to be executed")
+	}
+}
+
+func TestRunSavesOnEOF(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+
+	if err := newREPL(cfg).Run(strings.NewReader("set autocorrect on\n"), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+
+	restored := createTestConfig()
+	restored.SavePath = cfg.SavePath
+	if err := restored.loadGame(); err != nil {
+		t.Fatalf("loadGame returned an error: %v", err)
+	}
+	if !restored.AutoCorrect {
+		t.Error("Expected the session to be saved when input ends")
+	}
+}
+
+func TestExecuteCommandUnknown(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := executeCommand(cfg, "fly cinnabar"); !errors.Is(err, errUnknownCommand) {
+		t.Errorf("Expected errUnknownCommand, got %v", err)
+	}
+	if err := executeCommand(cfg, "   "); err != nil {
+		t.Errorf("Blank input should be ignored, got %v", err)
+	}
+	if err := executeCommand(cfg, "exit"); !errors.Is(err, errExit) {
+		t.Errorf("Expected errExit from the exit command, got %v", err)
+	}
+}
+
+func TestRunWritesToOut(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+
+	if err := newREPL(cfg).Run(strings.NewReader("bag\nexit\n"), &out); err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Your bag:") || !strings.Contains(out.String(), "Goodbye!") {
+		t.Errorf("Expected command output in out, got %q", out.String())
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// saveFileVersion 2 replaced the map of caught species with individual
+// Pokémon in a party and PC boxes.
+const saveFileVersion = 2
+
+// saveFile is the on-disk representation of a game session.
+type saveFile struct {
+	Version       int                       `json:"version"`
+	SavedAt       time.Time                 `json:"saved_at"`
+	Pokemon       map[string]*CaughtPokemon `json:"pokemon"`
+	Party         []string                  `json:"party"`
+	Boxes         [][]string                `json:"boxes,omitempty"`
+	Bag           Inventory                 `json:"bag"`
+	CurrentArea   string                    `json:"current_area,omitempty"`
+	ExploredAreas []string                  `json:"explored_areas,omitempty"`
+	Trainer       *Trainer                  `json:"trainer"`
+	Pokedex       map[string]DexEntry       `json:"pokedex"`
+	AutoCorrect   bool                      `json:"autocorrect"`
+	Seed          uint64                    `json:"seed"`
+	RNGState      []byte                    `json:"rng_state,omitempty"` // Where the seeded sequence had got to
+
+	// LegacyPokemon holds the caught species of version 1 save files.
+	LegacyPokemon map[string]pokeapi.PokemonInfo `json:"pokemon_caught,omitempty"`
+}
+
+// defaultSavePath returns the save file used when none is given on the
+// command line.
+func defaultSavePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".pokedexcli_save.json")
+}
+
+// saveGame writes the session to cfg.SavePath. It does nothing when no save
+// path is configured.
+func (cfg *Config) saveGame() error {
+	if cfg.SavePath == "" {
+		return nil
+	}
+
+	rngState, err := cfg.rngSource.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode random state: %v", err)
+	}
+
+	trainer := cfg.Trainer
+	trainer.PlayTime = cfg.playTime()
+	data, err := json.MarshalIndent(saveFile{
+		Version:       saveFileVersion,
+		SavedAt:       time.Now(),
+		Pokemon:       cfg.PokemonCaught,
+		Party:         cfg.Party,
+		Boxes:         cfg.Boxes,
+		Bag:           cfg.Bag,
+		CurrentArea:   cfg.CurrentArea,
+		ExploredAreas: cfg.ExploredAreas,
+		Trainer:       &trainer,
+		Pokedex:       cfg.Pokedex,
+		AutoCorrect:   cfg.AutoCorrect,
+		Seed:          cfg.Seed,
+		RNGState:      rngState,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save file: %v", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a torn save.
+	tmpPath := cfg.SavePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write save file: %v", err)
+	}
+	if err := os.Rename(tmpPath, cfg.SavePath); err != nil {
+		return fmt.Errorf("failed to write save file: %v", err)
+	}
+	return nil
+}
+
+// loadGame restores the session from cfg.SavePath. A missing save file
+// leaves the config untouched.
+func (cfg *Config) loadGame() error {
+	if cfg.SavePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(cfg.SavePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read save file: %v", err)
+	}
+
+	var save saveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return fmt.Errorf("failed to parse save file %s: %v", cfg.SavePath, err)
+	}
+	if save.Version > saveFileVersion {
+		return fmt.Errorf("save file %s was written by a newer version (%d)", cfg.SavePath, save.Version)
+	}
+
+	if save.Pokemon != nil {
+		if err := checkStorage(save.Pokemon, save.Party, save.Boxes); err != nil {
+			return fmt.Errorf("save file %s is damaged: %v", cfg.SavePath, err)
+		}
+		cfg.PokemonCaught = save.Pokemon
+		cfg.Party = save.Party
+		cfg.Boxes = save.Boxes
+	}
+	if save.Pokedex != nil {
+		cfg.Pokedex = save.Pokedex
+	}
+	cfg.migrateLegacyPokemon(save.LegacyPokemon, save.SavedAt)
+	// Saves from before the Pokédex was tracked only know what was caught.
+	for _, pokemon := range cfg.PokemonCaught {
+		cfg.markCaught(pokemon.Info.SpeciesName(), pokemon.Info.SpeciesID())
+	}
+	if save.Bag != nil {
+		cfg.Bag = save.Bag
+	}
+	cfg.CurrentArea = save.CurrentArea
+	cfg.ExploredAreas = save.ExploredAreas
+	if save.Trainer != nil {
+		cfg.Trainer = *save.Trainer
+	} else {
+		// Saves from before trainer profiles began when they were written.
+		cfg.Trainer.StartedAt = save.SavedAt
+	}
+	cfg.sessionStart = time.Now()
+	cfg.AutoCorrect = save.AutoCorrect
+
+	// Continue the saved random sequence rather than starting it over, so
+	// reloading doesn't repeat the same catches.
+	if len(save.RNGState) > 0 {
+		cfg.reseed(save.Seed)
+		if err := cfg.rngSource.UnmarshalBinary(save.RNGState); err != nil {
+			return fmt.Errorf("failed to restore random state from %s: %v", cfg.SavePath, err)
+		}
+		cfg.rngResumed = true
+	}
+	return nil
+}
+
+// checkStorage reports Pokémon that aren't kept exactly once in the party
+// or a box, and party or box IDs that name no Pokémon.
+func checkStorage(pokemon map[string]*CaughtPokemon, party []string, boxes [][]string) error {
+	stored := make(map[string]bool, len(pokemon))
+	store := func(where string, ids []string, size int) error {
+		if len(ids) > size {
+			return fmt.Errorf("%s holds %d Pokémon, but only %d fit", where, len(ids), size)
+		}
+		for _, id := range ids {
+			if pokemon[id] == nil {
+				return fmt.Errorf("%s holds unknown Pokémon %s", where, id)
+			}
+			if stored[id] {
+				return fmt.Errorf("the Pokémon %s is stored twice", id)
+			}
+			stored[id] = true
+		}
+		return nil
+	}
+
+	if err := store("the party", party, partySize); err != nil {
+		return err
+	}
+	for i, box := range boxes {
+		if err := store(fmt.Sprintf("box %d", i+1), box, boxSize); err != nil {
+			return err
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(pokemon)) {
+		if pokemon[id] == nil || pokemon[id].ID != id {
+			return fmt.Errorf("the Pokémon %s is saved under the wrong ID", id)
+		}
+		if !stored[id] {
+			return fmt.Errorf("the Pokémon %s is in neither the party nor a box", id)
+		}
+	}
+	return nil
+}
+
+// migrateLegacyPokemon turns the species caught in a version 1 save into
+// individual Pokémon, filling the party in Pokédex order and boxing the
+// rest. Levels weren't tracked then, so they all start at defaultLevel.
+func (cfg *Config) migrateLegacyPokemon(legacy map[string]pokeapi.PokemonInfo, caughtAt time.Time) {
+	species := slices.SortedFunc(maps.Values(legacy), func(a, b pokeapi.PokemonInfo) int {
+		return cmp.Or(cmp.Compare(a.ID, b.ID), cmp.Compare(a.Name, b.Name))
+	})
+	for _, info := range species {
+		cfg.addCaught(&CaughtPokemon{
+			ID:       cfg.newPokemonID(),
+			Species:  info.Name,
+			Level:    defaultLevel,
+			CaughtAt: caughtAt,
+			Info:     info,
+		})
+	}
+}
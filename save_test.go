@@ -0,0 +1,173 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func TestSaveAndLoadGame(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+	pikachu := givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu", BaseExperience: 112})
+	pikachu.Nickname = "sparky"
+	cfg.Bag.Remove("master-ball")
+	cfg.CurrentArea = "viridian-forest-area"
+
+	if err := cfg.saveGame(); err != nil {
+		t.Fatalf("saveGame returned an error: %v", err)
+	}
+
+	restored := createTestConfig()
+	restored.SavePath = cfg.SavePath
+	if err := restored.loadGame(); err != nil {
+		t.Fatalf("loadGame returned an error: %v", err)
+	}
+
+	pokemon, caught := restored.PokemonCaught[pikachu.ID]
+	if !caught || pokemon.Info.BaseExperience != 112 || pokemon.Nickname != "sparky" {
+		t.Errorf("Expected pikachu to be restored, got %+v", restored.PokemonCaught)
+	}
+	if len(restored.Party) != 1 || restored.Party[0] != pikachu.ID {
+		t.Errorf("Expected pikachu in the party, got %v", restored.Party)
+	}
+	if status := restored.dexStatus("pikachu"); status != "caught" {
+		t.Errorf("Expected pikachu to stay caught in the Pokédex, got %s", status)
+	}
+	if restored.Bag["master-ball"] != 0 {
+		t.Errorf("Expected the used master-ball to stay used, got %d", restored.Bag["master-ball"])
+	}
+	if restored.CurrentArea != "viridian-forest-area" {
+		t.Errorf("Expected to still be in viridian-forest-area, got %q", restored.CurrentArea)
+	}
+}
+
+func TestLoadGameMissingFile(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "missing.json")
+
+	if err := cfg.loadGame(); err != nil {
+		t.Errorf("A missing save file should not be an error, got %v", err)
+	}
+	if cfg.Bag["poke-ball"] == 0 {
+		t.Error("A new game should keep the starter inventory")
+	}
+}
+
+func TestLoadGameCorrupt(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+	os.WriteFile(cfg.SavePath, []byte("{not json"), 0o600)
+
+	if err := cfg.loadGame(); err == nil {
+		t.Error("Expected an error for a corrupt save file")
+	}
+}
+
+func TestLoadGameRejectsDanglingIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		party []string
+		boxes [][]string
+	}{
+		{"unknown party ID", []string{"a0000001", "deadbeef"}, nil},
+		{"unknown box ID", []string{"a0000001"}, [][]string{{"deadbeef"}}},
+		{"stored twice", []string{"a0000001"}, [][]string{{"a0000001"}}},
+		{"not stored", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+			givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+			for id, pokemon := range cfg.PokemonCaught {
+				delete(cfg.PokemonCaught, id)
+				pokemon.ID = "a0000001"
+				cfg.PokemonCaught[pokemon.ID] = pokemon
+			}
+			cfg.Party, cfg.Boxes = tt.party, tt.boxes
+			if err := cfg.saveGame(); err != nil {
+				t.Fatalf("saveGame returned an error: %v", err)
+			}
+
+			restored := createTestConfig()
+			restored.SavePath = cfg.SavePath
+			if err := restored.loadGame(); err == nil || !strings.Contains(err.Error(), "damaged") {
+				t.Errorf("Expected the save to be rejected as damaged, got %v", err)
+			}
+			if len(restored.PokemonCaught) != 0 {
+				t.Errorf("Expected nothing to be loaded, got %v", restored.PokemonCaught)
+			}
+		})
+	}
+}
+
+func TestSaveGameDisabled(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := cfg.saveGame(); err != nil {
+		t.Errorf("saveGame without a path should do nothing, got %v", err)
+	}
+}
+
+func TestLoadGameMigratesVersion1(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+	data := `{
+		"version": 1,
+		"saved_at": "2024-05-01T12:00:00Z",
+		"pokemon_caught": {
+			"pikachu": {"id": 25, "name": "pikachu"},
+			"bulbasaur": {"id": 1, "name": "bulbasaur"}
+		},
+		"bag": {"poke-ball": 3},
+		"lead": "pikachu"
+	}`
+	if err := os.WriteFile(cfg.SavePath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cfg.loadGame(); err != nil {
+		t.Fatalf("loadGame returned an error: %v", err)
+	}
+	party := cfg.partyPokemon()
+	if len(party) != 2 || party[0].Species != "bulbasaur" || party[1].Species != "pikachu" {
+		t.Fatalf("Expected both Pokémon in the party in Pokédex order, got %+v", party)
+	}
+	if party[0].Level != defaultLevel || party[0].CaughtAt.Year() != 2024 || party[0].ID == party[1].ID {
+		t.Errorf("Unexpected migrated Pokémon: %+v", party[0])
+	}
+}
+
+func TestSaveKeepsTrainerProfile(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+	if err := executeCommand(cfg, "PROFILE Name Ash K."); err != nil {
+		t.Fatalf("profile returned an error: %v", err)
+	}
+	cfg.Trainer.PlayTime = 90 * time.Minute
+	cfg.enterArea("route-1")
+
+	if err := cfg.saveGame(); err != nil {
+		t.Fatalf("saveGame returned an error: %v", err)
+	}
+	restored := createTestConfig()
+	restored.SavePath = cfg.SavePath
+	if err := restored.loadGame(); err != nil {
+		t.Fatalf("loadGame returned an error: %v", err)
+	}
+
+	if restored.Trainer.Name != "Ash K." || restored.Trainer.Money != startingMoney {
+		t.Errorf("Unexpected trainer: %+v", restored.Trainer)
+	}
+	if played := restored.playTime(); played < 90*time.Minute || formatPlayTime(played) != "1:30" {
+		t.Errorf("Expected the play time to carry over, got %v", played)
+	}
+	if len(restored.ExploredAreas) != 1 || restored.ExploredAreas[0] != "route-1" {
+		t.Errorf("Expected route-1 to stay explored, got %v", restored.ExploredAreas)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/output"
+)
+
+func commandSet(cfg *Config, commands []string) error {
+	if len(commands) < 2 {
+		return fmt.Errorf("set command requires an option and a value, e.g. set autocorrect on or set output json")
+	}
+
+	option, value := commands[0], commands[1]
+	switch option {
+	case "autocorrect":
+		enabled, err := parseOnOff(value)
+		if err != nil {
+			return err
+		}
+		cfg.AutoCorrect = enabled
+		cfg.notef("autocorrect is now %s\n", value)
+	case "output":
+		format, err := output.ParseFormat(value)
+		if err != nil {
+			return err
+		}
+		cfg.Output = format
+		cfg.notef("output format is now %s\n", format)
+	default:
+		return fmt.Errorf("unknown setting '%s'", option)
+	}
+	return nil
+}
+
+func parseOnOff(value string) (bool, error) {
+	switch value {
+	case "on", "true", "yes":
+		return true, nil
+	case "off", "false", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected on or off, got '%s'", value)
+}
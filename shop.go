@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+	"github.com/OttScott/pokedexcli/internal/render"
+)
+
+// shopStock lists the items the Poké Mart sells. Prices are the items' cost
+// in PokeAPI.
+var shopStock = []string{
+	"poke-ball", "great-ball", "ultra-ball",
+	"potion", "super-potion", "hyper-potion", "max-potion",
+}
+
+// maxPurchase bounds how many of an item can be bought at once.
+const maxPurchase = 99
+
+type shopRecord struct {
+	Item  string `json:"item"`
+	Price int    `json:"price"`
+	InBag int    `json:"in_bag"`
+}
+
+// shopItem fetches an item the shop sells.
+func (cfg *Config) shopItem(name string) (*pokeapi.Item, error) {
+	if !slices.Contains(shopStock, name) {
+		return nil, fmt.Errorf("the Poké Mart doesn't sell %s; use shop to see what it does", name)
+	}
+	item, err := pokeapi.GetItem(cfg.cache, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch item info for '%s': %v", name, err)
+	}
+	return item, nil
+}
+
+func commandShop(cfg *Config, commands []string) error {
+	records := make([]shopRecord, 0, len(shopStock))
+	for _, name := range shopStock {
+		item, err := cfg.shopItem(name)
+		if err != nil {
+			return err
+		}
+		records = append(records, shopRecord{Item: item.Name, Price: item.Cost, InBag: cfg.Bag[item.Name]})
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Welcome to the Poké Mart! You have ₽%d.\n", cfg.Trainer.Money)
+	table := render.NewTable(cfg.style.Bold("ITEM"), cfg.style.Bold("PRICE"), cfg.style.Bold("IN BAG"))
+	table.Indent = "  "
+	for _, record := range records {
+		table.AddRow(record.Item, fmt.Sprintf("₽%d", record.Price), fmt.Sprint(record.InBag))
+	}
+	if err := table.Write(cfg.out); err != nil {
+		return err
+	}
+	fmt.Fprintln(cfg.out, "Use buy <item> [quantity] to buy something.")
+	return nil
+}
+
+func commandBuy(cfg *Config, commands []string) error {
+	if len(commands) < 1 {
+		return fmt.Errorf("buy command requires an item name, optionally followed by a quantity")
+	}
+	quantity := 1
+	if len(commands) > 1 {
+		var err error
+		quantity, err = strconv.Atoi(commands[1])
+		if err != nil || quantity < 1 || quantity > maxPurchase {
+			return fmt.Errorf("quantity must be a number from 1 to %d, got '%s'", maxPurchase, commands[1])
+		}
+	}
+
+	item, err := cfg.shopItem(commands[0])
+	if err != nil {
+		return err
+	}
+	total := item.Cost * quantity
+	if total > cfg.Trainer.Money {
+		return fmt.Errorf("%d %s cost ₽%d, but you only have ₽%d", quantity, item.Name, total, cfg.Trainer.Money)
+	}
+
+	cfg.Trainer.Money -= total
+	cfg.Bag.Add(item.Name, quantity)
+	cfg.notef("You bought %d %s for ₽%d. You have ₽%d left.\n", quantity, item.Name, total, cfg.Trainer.Money)
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// seedShop stores every item the shop sells with the same price.
+func seedShop(cfg *Config, price int) {
+	for _, name := range shopStock {
+		body := fmt.Sprintf(`{"id": 1, "name": %q, "cost": %d}`, name, price)
+		cfg.cache.Add("https://pokeapi.co/api/v2/item/"+name, []byte(body))
+	}
+}
+
+func TestBuySpendsMoney(t *testing.T) {
+	cfg := createTestConfig()
+	seedShop(cfg, 200)
+	balls := cfg.Bag["poke-ball"]
+
+	if err := commandBuy(cfg, []string{"poke-ball", "5"}); err != nil {
+		t.Fatalf("buy returned an error: %v", err)
+	}
+	if cfg.Bag["poke-ball"] != balls+5 || cfg.Trainer.Money != startingMoney-1000 {
+		t.Errorf("Expected 5 more poke-balls for ₽1000, have %d and ₽%d", cfg.Bag["poke-ball"], cfg.Trainer.Money)
+	}
+
+	if err := commandBuy(cfg, []string{"potion", "11"}); err == nil {
+		t.Error("Expected an error buying more than the player can afford")
+	}
+	if cfg.Bag["potion"] != 0 || cfg.Trainer.Money != startingMoney-1000 {
+		t.Errorf("A failed purchase shouldn't change anything, have %d potions and ₽%d", cfg.Bag["potion"], cfg.Trainer.Money)
+	}
+	for _, args := range [][]string{{"rare-candy"}, {"potion", "0"}, {"potion", "lots"}, {}} {
+		if err := commandBuy(cfg, args); err == nil {
+			t.Errorf("Expected an error for buy %v", args)
+		}
+	}
+}
+
+func TestShopListsPrices(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	seedShop(cfg, 300)
+
+	if err := commandShop(cfg, nil); err != nil {
+		t.Fatalf("shop returned an error: %v", err)
+	}
+	for _, want := range []string{"You have ₽3000", "hyper-potion", "₽300"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the shop listing, got %q", want, out.String())
+		}
+	}
+}
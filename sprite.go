@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// showSprite draws a caught Pokémon's sprite. Pokémon saved before sprites
+// were recorded are looked up again to learn their sprite URLs.
+func showSprite(cfg *Config, pokemon pokeapi.PokemonInfo, variant pokeapi.SpriteVariant) error {
+	sprites := pokemon.Sprites
+	if sprites == (pokeapi.PokemonSprites{}) {
+		info, err := pokeapi.GetPokemonInfo(cfg.cache, pokemon.Name)
+		if err != nil {
+			return fmt.Errorf("failed to fetch sprites for '%s': %v", pokemon.Name, err)
+		}
+		sprites = info.Sprites
+	}
+
+	url := sprites.URL(variant)
+	if url == "" && variant.Female {
+		// Most Pokémon look the same either way and have no female sprite.
+		variant.Female = false
+		url = sprites.URL(variant)
+	}
+	if url == "" {
+		return fmt.Errorf("%s has no %s sprite", pokemon.Name, variant)
+	}
+
+	data, err := pokeapi.GetSprite(cfg.cache, url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sprite for '%s': %v", pokemon.Name, err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode sprite for '%s': %v", pokemon.Name, err)
+	}
+	fmt.Fprint(cfg.out, cfg.style.Sprite(img))
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func seedSprite(t *testing.T, cfg *Config, url string) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		img.Set(x, 0, color.Black)
+		img.Set(x, 1, color.Black)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	cfg.cache.Add(url, buf.Bytes())
+}
+
+func TestInspectSprite(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	pikachu := pokeapi.PokemonInfo{ID: 25, Name: "pikachu"}
+	pikachu.Sprites.FrontShiny = "https://example.com/shiny.png"
+	givePokemon(cfg, pikachu)
+	seedSprite(t, cfg, pikachu.Sprites.FrontShiny)
+
+	// No separate female sprite, so the shiny one is drawn.
+	if err := commandInspect(cfg, []string{"pikachu", "--shiny", "--female"}); err != nil {
+		t.Fatalf("inspect --shiny returned an error: %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "Stats:\n@@\n") {
+		t.Errorf("Expected the sprite after the stats, got %q", out.String())
+	}
+
+	err := commandInspect(cfg, []string{"pikachu", "--back"})
+	if err == nil || !strings.Contains(err.Error(), "no back sprite") {
+		t.Errorf("Expected a missing sprite error, got %v", err)
+	}
+}
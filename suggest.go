@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OttScott/pokedexcli/internal/fuzzy"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+const (
+	maxSuggestions = 3
+	// nameIndexPageSize is large enough to load most name lists in one request.
+	nameIndexPageSize = 2000
+)
+
+// knownNames returns every resource name of a list endpoint, loading the
+// list once per session. It returns nil if the list can't be fetched, in
+// which case no suggestions are offered.
+func (cfg *Config) knownNames(endpoint string) []string {
+	if names, loaded := cfg.nameIndex[endpoint]; loaded {
+		return names
+	}
+
+	var names []string
+	for resource, err := range pokeapi.Resources(cfg.cache, endpoint, nameIndexPageSize) {
+		if err != nil {
+			return nil
+		}
+		names = append(names, resource.Name)
+	}
+
+	if cfg.nameIndex == nil {
+		cfg.nameIndex = make(map[string][]string)
+	}
+	cfg.nameIndex[endpoint] = names
+	return names
+}
+
+// correctName looks for candidates close to a name that wasn't found. It
+// returns a hint listing the suggestions, suitable for appending to a
+// message, and the best match when autocorrect is enabled and that match is
+// unambiguous.
+func (cfg *Config) correctName(name string, candidates []string) (corrected, hint string) {
+	var matches []fuzzy.Match
+	for _, match := range fuzzy.Suggest(name, candidates, maxSuggestions+1) {
+		if !strings.EqualFold(match.Name, name) {
+			matches = append(matches, match)
+		}
+	}
+	if len(matches) == 0 {
+		return "", ""
+	}
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = match.Name
+	}
+	hint = fmt.Sprintf(" (did you mean %s?)", strings.Join(names, ", "))
+
+	if cfg.AutoCorrect && (len(matches) == 1 || matches[0].Score < matches[1].Score) {
+		corrected = matches[0].Name
+	}
+	return corrected, hint
+}
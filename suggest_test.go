@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func TestCorrectName(t *testing.T) {
+	cfg := createTestConfig()
+	candidates := []string{"pikachu", "raichu", "charizard"}
+
+	corrected, hint := cfg.correctName("charzard", candidates)
+	if corrected != "" {
+		t.Errorf("Autocorrect is off, expected no correction, got %q", corrected)
+	}
+	if !strings.Contains(hint, "charizard") {
+		t.Errorf("Expected hint to suggest charizard, got %q", hint)
+	}
+
+	cfg.AutoCorrect = true
+	corrected, _ = cfg.correctName("charzard", candidates)
+	if corrected != "charizard" {
+		t.Errorf("Expected autocorrect to charizard, got %q", corrected)
+	}
+
+	corrected, hint = cfg.correctName("zzzzzzzz", candidates)
+	if corrected != "" || hint != "" {
+		t.Errorf("Expected no suggestions for an unrelated name, got %q %q", corrected, hint)
+	}
+}
+
+func TestCorrectNameAmbiguous(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.AutoCorrect = true
+
+	// pikchu is one edit away from both pichu and pikachu.
+	corrected, hint := cfg.correctName("pikchu", []string{"pikachu", "pichu"})
+	if corrected != "" {
+		t.Errorf("Ambiguous matches should not be autocorrected, got %q", corrected)
+	}
+	if !strings.Contains(hint, "pichu") || !strings.Contains(hint, "pikachu") {
+		t.Errorf("Expected both candidates in the hint, got %q", hint)
+	}
+}
+
+func TestKnownNamesCached(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon?offset=0&limit=2000", []byte(`{
+		"count": 2,
+		"results": [{"name": "bulbasaur", "url": ""}, {"name": "ivysaur", "url": ""}]
+	}`))
+
+	names := cfg.knownNames(pokeapi.EndpointPokemon)
+	if len(names) != 2 || names[0] != "bulbasaur" {
+		t.Fatalf("Unexpected names: %v", names)
+	}
+	if _, loaded := cfg.nameIndex[pokeapi.EndpointPokemon]; !loaded {
+		t.Error("Expected names to be kept in the index")
+	}
+}
+
+func TestInspectAutocorrect(t *testing.T) {
+	cfg := createTestConfig()
+	givePokemon(cfg, pokeapi.PokemonInfo{Name: "bulbasaur"})
+
+	err := commandInspect(cfg, []string{"bulbsaur"})
+	if err == nil || !strings.Contains(err.Error(), "did you mean bulbasaur?") {
+		t.Errorf("Expected a suggestion without autocorrect, got %v", err)
+	}
+
+	cfg.AutoCorrect = true
+	if err := commandInspect(cfg, []string{"bulbsaur"}); err != nil {
+		t.Errorf("inspect with autocorrect returned an error: %v", err)
+	}
+}
+
+func TestCommandSet(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandSet(cfg, []string{"autocorrect", "on"}); err != nil {
+		t.Fatalf("set autocorrect on returned an error: %v", err)
+	}
+	if !cfg.AutoCorrect {
+		t.Error("Expected autocorrect to be enabled")
+	}
+	if err := commandSet(cfg, []string{"autocorrect", "maybe"}); err == nil {
+		t.Error("Expected an error for an invalid value")
+	}
+	if err := commandSet(cfg, []string{"volume", "11"}); err == nil {
+		t.Error("Expected an error for an unknown setting")
+	}
+	if err := commandSet(cfg, nil); err == nil {
+		t.Error("Expected an error when no option is given")
+	}
+}
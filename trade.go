@@ -0,0 +1,398 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+const tradeFileVersion = 1
+
+// tradeFile is a Pokémon on its way to another player. The payload is
+// signed with the sender's key, and only files signed with the key of a
+// partner the receiver has trusted are imported, so a file edited by anyone
+// but the sender is rejected. The Pokémon in it is checked on import as well,
+// in case a trusted partner's save has been edited.
+type tradeFile struct {
+	Version   int             `json:"version"`
+	PublicKey []byte          `json:"public_key"`
+	Payload   json.RawMessage `json:"payload"` // An encoded tradePayload, exactly as signed
+	Signature []byte          `json:"signature"`
+}
+
+type tradePayload struct {
+	Trainer    string         `json:"trainer"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Pokemon    *CaughtPokemon `json:"pokemon"`
+}
+
+// tradeKey returns the trainer's signing key, creating it the first time.
+func (cfg *Config) tradeKey() (ed25519.PrivateKey, error) {
+	if len(cfg.Trainer.TradeKey) != ed25519.SeedSize {
+		seed := make([]byte, ed25519.SeedSize)
+		if _, err := rand.Read(seed); err != nil {
+			return nil, fmt.Errorf("failed to create trade key: %v", err)
+		}
+		cfg.Trainer.TradeKey = seed
+	}
+	return ed25519.NewKeyFromSeed(cfg.Trainer.TradeKey), nil
+}
+
+func commandTrade(cfg *Config, commands []string) error {
+	if len(commands) > 0 {
+		switch strings.ToLower(commands[0]) {
+		case "export":
+			if len(commands) == 3 {
+				return cfg.exportTrade(commands[1], commands[2])
+			}
+		case "import":
+			if len(commands) == 2 {
+				return cfg.importTrade(commands[1])
+			}
+		case "key":
+			if len(commands) == 1 {
+				return cfg.showTradeKey()
+			}
+		case "trust":
+			switch len(commands) {
+			case 1:
+				return cfg.showTradePartners()
+			case 3:
+				return cfg.trustPartner(commands[1], commands[2])
+			}
+		}
+	}
+	return fmt.Errorf("use trade export <pokemon> <file>, trade import <file>, trade key or trade trust <name> <key>")
+}
+
+// showTradeKey prints the public half of the trainer's trade key, for
+// partners to trust.
+func (cfg *Config) showTradeKey() error {
+	key, err := cfg.tradeKey()
+	if err != nil {
+		return err
+	}
+	record := tradeKeyRecord{Trainer: cfg.Trainer.Name, Key: hex.EncodeToString(key.Public().(ed25519.PublicKey))}
+	if handled, err := cfg.emit([]tradeKeyRecord{record}); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Your trade key is %s\n", record.Key)
+	fmt.Fprintln(cfg.out, "Players you trade with can add it with trade trust <your name> <key>.")
+	return nil
+}
+
+func (cfg *Config) showTradePartners() error {
+	records := []tradeKeyRecord{}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Trainer.TradePartners)) {
+		records = append(records, tradeKeyRecord{Trainer: name, Key: hex.EncodeToString(cfg.Trainer.TradePartners[name])})
+	}
+	if handled, err := cfg.emit(records); handled {
+		return err
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(cfg.out, "You don't trust anyone to trade with yet. Add a partner's key with trade trust <name> <key>.")
+		return nil
+	}
+	fmt.Fprintln(cfg.out, "You accept trades from:")
+	for _, record := range records {
+		fmt.Fprintf(cfg.out, " - %s (%s)\n", record.Trainer, record.Key)
+	}
+	return nil
+}
+
+// trustPartner accepts trade files signed with key from now on.
+func (cfg *Config) trustPartner(name, key string) error {
+	if err := checkName("trainer name", name); err != nil {
+		return err
+	}
+	publicKey, err := hex.DecodeString(key)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("'%s' is not a trade key; ask %s to run trade key", key, name)
+	}
+	own, err := cfg.tradeKey()
+	if err != nil {
+		return err
+	}
+	if own.Public().(ed25519.PublicKey).Equal(ed25519.PublicKey(publicKey)) {
+		return fmt.Errorf("that is your own trade key")
+	}
+
+	if cfg.Trainer.TradePartners == nil {
+		cfg.Trainer.TradePartners = make(map[string][]byte)
+	}
+	cfg.Trainer.TradePartners[name] = publicKey
+	cfg.notef("You now accept trades from %s.\n", name)
+	return nil
+}
+
+// tradePartner returns the name a public key was trusted under.
+func (cfg *Config) tradePartner(publicKey []byte) (string, bool) {
+	for name, key := range cfg.Trainer.TradePartners {
+		if ed25519.PublicKey(key).Equal(ed25519.PublicKey(publicKey)) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// exportTrade writes a Pokémon to a signed trade file and sends it away.
+func (cfg *Config) exportTrade(ref, path string) error {
+	pokemon, err := cfg.findPokemon(ref)
+	if err != nil {
+		return err
+	}
+	if err := cfg.checkCanPart(pokemon); err != nil {
+		return err
+	}
+	key, err := cfg.tradeKey()
+	if err != nil {
+		return err
+	}
+
+	traded := *pokemon
+	if traded.OriginalTrainer == "" {
+		traded.OriginalTrainer = cfg.Trainer.Name
+	}
+	payload, err := json.Marshal(tradePayload{Trainer: cfg.Trainer.Name, ExportedAt: time.Now(), Pokemon: &traded})
+	if err != nil {
+		return fmt.Errorf("failed to encode trade: %v", err)
+	}
+	data, err := json.Marshal(tradeFile{
+		Version:   tradeFileVersion,
+		PublicKey: key.Public().(ed25519.PublicKey),
+		Payload:   payload,
+		Signature: ed25519.Sign(key, payload),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode trade: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write trade file: %v", err)
+	}
+
+	cfg.removePokemon(pokemon)
+	cfg.notef("%s was sent away in %s. Take good care of it!\n", pokemon.Name(), path)
+	return nil
+}
+
+// readTradeFile loads a trade file, checks that it hasn't been corrupted
+// since it was signed and that the Pokémon in it is one that could exist.
+func readTradeFile(path string) (*tradePayload, *tradeFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read trade file: %v", err)
+	}
+	var file tradeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse trade file %s: %v", path, err)
+	}
+	if file.Version != tradeFileVersion {
+		return nil, nil, fmt.Errorf("trade file %s has unsupported version %d", path, file.Version)
+	}
+	if len(file.PublicKey) != ed25519.PublicKeySize || !ed25519.Verify(file.PublicKey, file.Payload, file.Signature) {
+		return nil, nil, fmt.Errorf("trade file %s has been tampered with", path)
+	}
+
+	var payload tradePayload
+	if err := json.Unmarshal(file.Payload, &payload); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse trade file %s: %v", path, err)
+	}
+	if payload.Pokemon == nil || payload.Pokemon.ID == "" || payload.Pokemon.Species == "" {
+		return nil, nil, fmt.Errorf("trade file %s has no Pokémon in it", path)
+	}
+	if err := checkTradedPokemon(&payload); err != nil {
+		return nil, nil, fmt.Errorf("trade file %s is invalid: %v", path, err)
+	}
+	return &payload, &file, nil
+}
+
+// checkTradedPokemon rejects a traded Pokémon with any field no Pokémon of
+// this game could have. Fields that depend on the species are checked by
+// checkTradedTraits once it has been looked up; Shiny can be either.
+func checkTradedPokemon(payload *tradePayload) error {
+	pokemon := payload.Pokemon
+	if !validPokemonID(pokemon.ID) {
+		return fmt.Errorf("'%s' is not a Pokémon ID", pokemon.ID)
+	}
+	if !isSlug(pokemon.Species) {
+		return fmt.Errorf("'%s' is not a Pokémon name", pokemon.Species)
+	}
+	if pokemon.Nickname != "" {
+		if err := checkNickname(pokemon.Nickname); err != nil {
+			return err
+		}
+	}
+	if err := checkName("trainer name", payload.Trainer); err != nil {
+		return err
+	}
+	if err := checkName("trainer name", pokemon.OriginalTrainer); err != nil {
+		return fmt.Errorf("original trainer: %v", err)
+	}
+	if pokemon.Location != "" && !isSlug(pokemon.Location) {
+		return fmt.Errorf("'%s' is not a location area name", pokemon.Location)
+	}
+	if pokemon.CaughtAt.IsZero() || pokemon.CaughtAt.After(payload.ExportedAt) {
+		return fmt.Errorf("it can't have been caught at %s", pokemon.CaughtAt.Format(time.RFC3339))
+	}
+
+	if pokemon.Level < 1 || pokemon.Level > pokeapi.MaxLevel {
+		return fmt.Errorf("level %d is not between 1 and %d", pokemon.Level, pokeapi.MaxLevel)
+	}
+	if pokemon.Experience < 0 {
+		return fmt.Errorf("experience %d is negative", pokemon.Experience)
+	}
+	totalEVs := 0
+	for _, stat := range battle.StatNames {
+		if iv := pokemon.IVs.Get(stat); iv < 0 || iv > maxIV {
+			return fmt.Errorf("%s IV %d is not between 0 and %d", stat, iv, maxIV)
+		}
+		ev := pokemon.EVs.Get(stat)
+		if ev < 0 || ev > battle.MaxEV {
+			return fmt.Errorf("%s EV %d is not between 0 and %d", stat, ev, battle.MaxEV)
+		}
+		totalEVs += ev
+	}
+	if totalEVs > battle.MaxTotalEVs {
+		return fmt.Errorf("%d EVs in all is more than %d", totalEVs, battle.MaxTotalEVs)
+	}
+
+	// Pokémon from version 1 saves have no nature or gender.
+	if pokemon.Nature != "" && !slices.Contains(natureNames, pokemon.Nature) {
+		return fmt.Errorf("'%s' is not a nature", pokemon.Nature)
+	}
+	if pokemon.Nature == "" && (pokemon.IncreasedStat != "" || pokemon.DecreasedStat != "") {
+		return fmt.Errorf("it has nature stats without a nature")
+	}
+	if !slices.Contains([]string{"", "male", "female", "genderless"}, pokemon.Gender) {
+		return fmt.Errorf("'%s' is not a gender", pokemon.Gender)
+	}
+	return nil
+}
+
+// checkTradedTraits checks the fields of a traded Pokémon that depend on its
+// species: its nature's stats, its gender and its experience for its level.
+// Pokémon from older saves may have no experience, which is filled in.
+func (cfg *Config) checkTradedTraits(pokemon *CaughtPokemon, species *pokeapi.PokemonSpecies) error {
+	if pokemon.Nature != "" {
+		nature, err := pokeapi.GetNature(cfg.cache, pokemon.Nature)
+		if err != nil {
+			return fmt.Errorf("failed to fetch nature info for '%s': %v", pokemon.Nature, err)
+		}
+		var increased, decreased string
+		if nature.IncreasedStat != nil && nature.DecreasedStat != nil {
+			increased, decreased = nature.IncreasedStat.Name, nature.DecreasedStat.Name
+		}
+		if pokemon.IncreasedStat != increased || pokemon.DecreasedStat != decreased {
+			return fmt.Errorf("its nature stats don't match its %s nature", pokemon.Nature)
+		}
+	}
+
+	switch {
+	case pokemon.Gender == "":
+	case species.GenderRate < 0 && pokemon.Gender != "genderless",
+		species.GenderRate >= 0 && pokemon.Gender == "genderless",
+		species.GenderRate == 0 && pokemon.Gender == "female",
+		species.GenderRate == 8 && pokemon.Gender == "male":
+		return fmt.Errorf("%s can't be %s", species.Name, pokemon.Gender)
+	}
+
+	rate, err := cfg.growthRate(species)
+	if err != nil || rate == nil {
+		return err
+	}
+	pokemon.Experience = max(pokemon.Experience, rate.ExperienceAt(pokemon.Level))
+	if level := rate.LevelFor(pokemon.Experience); min(level, pokeapi.MaxLevel) != pokemon.Level {
+		return fmt.Errorf("experience %d is for level %d, not %d", pokemon.Experience, level, pokemon.Level)
+	}
+	return nil
+}
+
+// isSlug reports whether s looks like a PokeAPI resource name.
+func isSlug(s string) bool {
+	return s != "" && !strings.ContainsFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-'
+	})
+}
+
+// importTrade receives the Pokémon in a trade file, evolving it if its
+// species evolves when traded.
+func (cfg *Config) importTrade(path string) error {
+	payload, file, err := readTradeFile(path)
+	if err != nil {
+		return err
+	}
+	pokemon := payload.Pokemon
+	key, err := cfg.tradeKey()
+	if err != nil {
+		return err
+	}
+	if key.Public().(ed25519.PublicKey).Equal(ed25519.PublicKey(file.PublicKey)) {
+		return fmt.Errorf("you can't trade with yourself")
+	}
+	partner, trusted := cfg.tradePartner(file.PublicKey)
+	if !trusted {
+		return fmt.Errorf("trade file %s wasn't signed by anyone you trust; add the sender's key with trade trust <name> <key>", path)
+	}
+	if _, owned := cfg.PokemonCaught[pokemon.ID]; owned {
+		return fmt.Errorf("you already have %s (ID %s)", pokemon.Name(), pokemon.ID)
+	}
+	// Every export is signed anew, so only replaying a file repeats a
+	// signature. The same Pokémon can still come back in a later trade.
+	sum := sha256.Sum256(file.Signature)
+	tradeID := hex.EncodeToString(sum[:])
+	if _, received := cfg.Trainer.TradesReceived[tradeID]; received {
+		return fmt.Errorf("trade file %s has already been imported", path)
+	}
+
+	// Look everything up before taking the Pokémon in, so a failed fetch
+	// leaves the trade file to be imported again later. The embedded Pokémon
+	// info is only the sender's word, so it is replaced too.
+	info, err := pokeapi.GetPokemonInfo(cfg.cache, pokemon.Species)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", pokemon.Species, err)
+	}
+	species, err := pokeapi.GetPokemonSpecies(cfg.cache, info.SpeciesName())
+	if err != nil {
+		return fmt.Errorf("failed to fetch species info for '%s': %v", pokemon.Species, err)
+	}
+	if err := cfg.checkTradedTraits(pokemon, species); err != nil {
+		return fmt.Errorf("trade file %s is invalid: %v", path, err)
+	}
+	link, err := cfg.evolutionLink(species)
+	if err != nil {
+		return err
+	}
+	var evolved *pokeapi.PokemonInfo
+	if link != nil {
+		if next, ok := link.TradeEvolution(); ok {
+			if evolved, err = pokeapi.GetPokemonInfo(cfg.cache, next); err != nil {
+				return fmt.Errorf("failed to fetch Pokémon info for '%s': %v", next, err)
+			}
+		}
+	}
+
+	pokemon.Info = *info
+	if cfg.Trainer.TradesReceived == nil {
+		cfg.Trainer.TradesReceived = make(map[string]time.Time)
+	}
+	cfg.Trainer.TradesReceived[tradeID] = time.Now()
+	where := cfg.addCaught(pokemon)
+	cfg.notef("%s sent over %s! It was sent to %s.\n", partner, pokemon.Name(), where)
+	if evolved != nil {
+		cfg.evolveInto(pokemon, evolved)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// seedKadabra caches a kadabra that evolves into alakazam when traded.
+func seedKadabra(cfg *Config) {
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon-species/kadabra", []byte(`{
+		"id": 64, "name": "kadabra",
+		"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/26/"}
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/evolution-chain/26/", []byte(`{
+		"id": 26,
+		"chain": {"species": {"name": "abra"}, "evolves_to": [{
+			"species": {"name": "kadabra"},
+			"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 16}],
+			"evolves_to": [{
+				"species": {"name": "alakazam"},
+				"evolution_details": [{"trigger": {"name": "trade"}}],
+				"evolves_to": []
+			}]
+		}]}
+	}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/kadabra", []byte(`{"id": 64, "name": "kadabra"}`))
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/alakazam", []byte(`{"id": 65, "name": "alakazam"}`))
+}
+
+// trust makes receiver accept trades from sender under name.
+func trust(t *testing.T, receiver *Config, name string, sender ed25519.PrivateKey) {
+	t.Helper()
+	key := hex.EncodeToString(sender.Public().(ed25519.PublicKey))
+	if err := commandTrade(receiver, []string{"trust", name, key}); err != nil {
+		t.Fatalf("trade trust returned an error: %v", err)
+	}
+}
+
+// senderKey returns cfg's trade key.
+func senderKey(t *testing.T, cfg *Config) ed25519.PrivateKey {
+	t.Helper()
+	key, err := cfg.tradeKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestTradeBetweenPlayers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kadabra.trade")
+
+	sender := createTestConfig()
+	sender.Trainer.Name = "ash"
+	givePokemon(sender, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+	kadabra := givePokemon(sender, pokeapi.PokemonInfo{ID: 64, Name: "kadabra"})
+	kadabra.Nickname = "spoony"
+	if err := commandTrade(sender, []string{"export", "spoony", path}); err != nil {
+		t.Fatalf("trade export returned an error: %v", err)
+	}
+	if _, kept := sender.PokemonCaught[kadabra.ID]; kept || len(sender.Party) != 1 {
+		t.Errorf("Expected kadabra to leave the sender's party, got %v", sender.Party)
+	}
+	if err := commandTrade(sender, []string{"import", path}); err == nil {
+		t.Error("Expected an error trading with yourself")
+	}
+
+	receiver := createTestConfig()
+	var out bytes.Buffer
+	receiver.out = &out
+	seedKadabra(receiver)
+	if err := commandTrade(receiver, []string{"import", path}); err == nil || !strings.Contains(err.Error(), "trust") {
+		t.Errorf("Expected a trade from a stranger to be rejected, got %v", err)
+	}
+	trust(t, receiver, "Ash", senderKey(t, sender))
+	if err := commandTrade(receiver, []string{"import", path}); err != nil {
+		t.Fatalf("trade import returned an error: %v", err)
+	}
+	received, ok := receiver.PokemonCaught[kadabra.ID]
+	if !ok || received.OriginalTrainer != "ash" || received.Nickname != "spoony" {
+		t.Fatalf("Expected ash's kadabra to arrive, got %+v", received)
+	}
+	if received.Species != "alakazam" || !strings.Contains(out.String(), "spoony evolved into alakazam!") {
+		t.Errorf("Expected kadabra to evolve when traded, got %s after %q", received.Species, out.String())
+	}
+
+	receiver.removePokemon(received)
+	if err := commandTrade(receiver, []string{"import", path}); err == nil || !strings.Contains(err.Error(), "already been imported") {
+		t.Errorf("Expected the same trade file to be rejected twice, got %v", err)
+	}
+}
+
+func TestTradeBackAndForth(t *testing.T) {
+	dir := t.TempDir()
+	a, b := createTestConfig(), createTestConfig()
+	givePokemon(a, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	givePokemon(b, pokeapi.PokemonInfo{ID: 4, Name: "charmander"})
+	pikachu := givePokemon(a, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+	seedPikachu(t, a)
+	seedPikachu(t, b)
+	trust(t, a, "b", senderKey(t, b))
+	trust(t, b, "a", senderKey(t, a))
+
+	// a -> b -> a -> b, each time in a new trade file.
+	trades := []struct{ from, to *Config }{{a, b}, {b, a}, {a, b}}
+	for i, trade := range trades {
+		path := filepath.Join(dir, fmt.Sprintf("trade-%d", i))
+		if err := commandTrade(trade.from, []string{"export", pikachu.ID, path}); err != nil {
+			t.Fatalf("trade %d: export returned an error: %v", i, err)
+		}
+		if err := commandTrade(trade.to, []string{"import", path}); err != nil {
+			t.Fatalf("trade %d: import returned an error: %v", i, err)
+		}
+	}
+	if _, ok := b.PokemonCaught[pikachu.ID]; !ok {
+		t.Error("Expected pikachu to end up with b")
+	}
+}
+
+func TestTradeImportFailsWithoutChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kadabra.trade")
+	sender := createTestConfig()
+	givePokemon(sender, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+	kadabra := givePokemon(sender, pokeapi.PokemonInfo{ID: 64, Name: "kadabra"})
+	if err := commandTrade(sender, []string{"export", kadabra.ID, path}); err != nil {
+		t.Fatalf("trade export returned an error: %v", err)
+	}
+
+	receiver := createTestConfig()
+	seedKadabra(receiver)
+	trust(t, receiver, "sender", senderKey(t, sender))
+	receiver.cache.Add("https://pokeapi.co/api/v2/pokemon/alakazam", []byte(`not json`))
+	if err := commandTrade(receiver, []string{"import", path}); err == nil {
+		t.Fatal("Expected an error when the trade evolution can't be looked up")
+	}
+	if len(receiver.PokemonCaught) != 0 || len(receiver.Trainer.TradesReceived) != 0 {
+		t.Fatal("A failed import must not take the Pokémon in or use up the trade file")
+	}
+
+	seedKadabra(receiver)
+	if err := commandTrade(receiver, []string{"import", path}); err != nil {
+		t.Fatalf("Expected the import to work once the lookup does, got %v", err)
+	}
+	if received := receiver.PokemonCaught[kadabra.ID]; received == nil || received.Species != "alakazam" {
+		t.Errorf("Expected kadabra to arrive and evolve, got %+v", received)
+	}
+}
+
+func TestTradeRejectsTamperedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pikachu.trade")
+	sender := createTestConfig()
+	givePokemon(sender, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	givePokemon(sender, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+	if err := commandTrade(sender, []string{"export", "pikachu", path}); err != nil {
+		t.Fatalf("trade export returned an error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), `"level":5`, `"level":100`, 1)
+	if tampered == string(data) {
+		t.Fatalf("Couldn't find the level to tamper with in %s", data)
+	}
+	if err := os.WriteFile(path, []byte(tampered), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	receiver := createTestConfig()
+	err = commandTrade(receiver, []string{"import", path})
+	if err == nil || !strings.Contains(err.Error(), "tampered") {
+		t.Errorf("Expected a tampered trade file to be rejected, got %v", err)
+	}
+	if len(receiver.PokemonCaught) != 0 {
+		t.Error("A tampered Pokémon must not be received")
+	}
+}
+
+// writeSignedTrade writes a trade file for payload signed with key.
+func writeSignedTrade(t *testing.T, path string, key ed25519.PrivateKey, payload tradePayload) {
+	t.Helper()
+	signed, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(tradeFile{
+		Version:   tradeFileVersion,
+		PublicKey: key.Public().(ed25519.PublicKey),
+		Payload:   signed,
+		Signature: ed25519.Sign(key, signed),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTradeRejectsResignedImpossiblePokemon(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	tests := []struct {
+		name string
+		edit func(*CaughtPokemon)
+	}{
+		{"nothing", func(p *CaughtPokemon) {}},
+		{"numeric ID", func(p *CaughtPokemon) { p.ID = "1" }},
+		{"species", func(p *CaughtPokemon) { p.Species = "../pikachu" }},
+		{"nickname length", func(p *CaughtPokemon) { p.Nickname = "averyverylongname" }},
+		{"nickname characters", func(p *CaughtPokemon) { p.Nickname = "spark\x1b[2J" }},
+		{"original trainer", func(p *CaughtPokemon) { p.OriginalTrainer = "" }},
+		{"caught later", func(p *CaughtPokemon) { p.CaughtAt = time.Now().Add(time.Hour) }},
+		{"level", func(p *CaughtPokemon) { p.Level = 101 }},
+		{"experience", func(p *CaughtPokemon) { p.Experience = 1000 }},
+		{"IV", func(p *CaughtPokemon) { p.IVs.Attack = 40 }},
+		{"EV", func(p *CaughtPokemon) { p.EVs.Speed = 253 }},
+		{"total EVs", func(p *CaughtPokemon) { p.EVs = battle.Stats{HP: 252, Attack: 252, Speed: 7} }},
+		{"nature", func(p *CaughtPokemon) { p.Nature = "grumpy" }},
+		{"nature stats", func(p *CaughtPokemon) { p.IncreasedStat = "speed" }},
+		{"gender", func(p *CaughtPokemon) { p.Gender = "genderless" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pokemon := &CaughtPokemon{
+				ID: "deadbeef", Species: "pikachu", Level: 5, Experience: 100,
+				Nature: "adamant", IncreasedStat: "attack", DecreasedStat: "special-attack",
+				Gender: "male", CaughtAt: time.Now().Add(-time.Hour), OriginalTrainer: "mallory",
+			}
+			tt.edit(pokemon)
+			path := filepath.Join(t.TempDir(), "pikachu.trade")
+			writeSignedTrade(t, path, key, tradePayload{Trainer: "mallory", ExportedAt: time.Now(), Pokemon: pokemon})
+
+			receiver := createTestConfig()
+			seedPikachu(t, receiver)
+			trust(t, receiver, "mallory", key)
+			owned := len(receiver.PokemonCaught)
+			err := commandTrade(receiver, []string{"import", path})
+			if tt.name == "nothing" {
+				if err != nil {
+					t.Fatalf("Expected a possible Pokémon to be received, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "invalid") {
+				t.Errorf("Expected an impossible Pokémon to be rejected, got %v", err)
+			}
+			if len(receiver.PokemonCaught) != owned {
+				t.Error("An impossible Pokémon must not be received")
+			}
+		})
+	}
+}
+
+func TestTradeRejectsResignedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pikachu.trade")
+	sender := createTestConfig()
+	givePokemon(sender, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	pikachu := givePokemon(sender, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+	receiver := createTestConfig()
+	seedPikachu(t, receiver)
+	trust(t, receiver, "ash", senderKey(t, sender))
+
+	// Someone without the sender's key edits the Pokémon and signs it again.
+	forged := *pikachu
+	forged.OriginalTrainer = "ash"
+	forged.IVs = battle.Stats{HP: 31, Attack: 31, Defense: 31, SpecialAttack: 31, SpecialDefense: 31, Speed: 31}
+	mallory := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	writeSignedTrade(t, path, mallory, tradePayload{Trainer: "ash", ExportedAt: time.Now(), Pokemon: &forged})
+
+	owned := len(receiver.PokemonCaught)
+	if err := commandTrade(receiver, []string{"import", path}); err == nil || !strings.Contains(err.Error(), "trust") {
+		t.Errorf("Expected a re-signed trade file to be rejected, got %v", err)
+	}
+	if len(receiver.PokemonCaught) != owned {
+		t.Error("A re-signed Pokémon must not be received")
+	}
+}
+
+func TestTradeTrust(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	if err := commandTrade(cfg, []string{"key"}); err != nil {
+		t.Fatalf("trade key returned an error: %v", err)
+	}
+	own := hex.EncodeToString(senderKey(t, cfg).Public().(ed25519.PublicKey))
+	if !strings.Contains(out.String(), own) {
+		t.Errorf("Expected the trade key to be shown, got %q", out.String())
+	}
+
+	for _, args := range [][]string{{"trust", "misty", "nothex"}, {"trust", "misty", "abcd"}, {"trust", "me", own}} {
+		if err := commandTrade(cfg, args); err == nil {
+			t.Errorf("Expected trade %v to be rejected", args)
+		}
+	}
+
+	partner := createTestConfig()
+	trust(t, cfg, "Misty", senderKey(t, partner))
+	out.Reset()
+	if err := commandTrade(cfg, []string{"trust"}); err != nil {
+		t.Fatalf("trade trust returned an error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Misty") {
+		t.Errorf("Expected Misty to be listed, got %q", out.String())
+	}
+}
+
+func TestTradeKeepsFilePathCase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Trades", "Pika.trade")
+	if err := os.Mkdir(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	cfg := createTestConfig()
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 1, Name: "bulbasaur"})
+	givePokemon(cfg, pokeapi.PokemonInfo{ID: 25, Name: "pikachu"})
+
+	if err := executeCommand(cfg, "TRADE Export Pikachu "+path); err != nil {
+		t.Fatalf("trade export returned an error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the trade file at %s: %v", path, err)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// startingMoney is what a new trainer sets out with, as in the mainline
+// games.
+const startingMoney = 3000
+
+// Trainer is the player's identity and progress.
+type Trainer struct {
+	Name         string               `json:"name"`
+	StartedAt    time.Time            `json:"started_at"`
+	PlayTime     time.Duration        `json:"play_time"` // Before the current session, in nanoseconds
+	Money        int                  `json:"money"`
+	BattlesWon   int                  `json:"battles_won"`
+	Achievements map[string]time.Time `json:"achievements,omitempty"` // When each achievement was unlocked, by ID
+	Badges       map[string]time.Time `json:"badges,omitempty"`       // When each gym badge was earned, by ID
+	// TradeKey is the seed of the ed25519 key that signs outgoing trades.
+	TradeKey []byte `json:"trade_key,omitempty"`
+	// TradePartners holds the public trade keys of the players whose trade
+	// files are accepted, by the name they were trusted under.
+	TradePartners map[string][]byte `json:"trade_partners,omitempty"`
+	// TradesReceived records when each trade file was imported, by a hash of
+	// its signature, so the same file can't be imported twice.
+	TradesReceived map[string]time.Time `json:"trades_received,omitempty"`
+}
+
+func newTrainer() Trainer {
+	return Trainer{
+		Name:         "trainer",
+		StartedAt:    time.Now(),
+		Money:        startingMoney,
+		Achievements: make(map[string]time.Time),
+		Badges:       make(map[string]time.Time),
+	}
+}
+
+// playTime is the total time played, including the current session.
+func (cfg *Config) playTime() time.Duration {
+	return cfg.Trainer.PlayTime + time.Since(cfg.sessionStart)
+}
+
+// exploreArea records that the player has been to a location area.
+func (cfg *Config) exploreArea(name string) {
+	for _, explored := range cfg.ExploredAreas {
+		if explored == name {
+			return
+		}
+	}
+	cfg.ExploredAreas = append(cfg.ExploredAreas, name)
+}
+
+type profileRecord struct {
+	Name          string    `json:"name"`
+	StartedAt     time.Time `json:"started_at"`
+	PlaySeconds   int       `json:"play_seconds"`
+	Money         int       `json:"money"`
+	Caught        int       `json:"caught"`
+	Species       int       `json:"species"`
+	ExploredAreas int       `json:"explored_areas"`
+	BattlesWon    int       `json:"battles_won"`
+	Achievements  int       `json:"achievements"`
+	Badges        []string  `json:"badges"`
+}
+
+// formatPlayTime renders a duration as hours and minutes, like a game's
+// trainer card.
+func formatPlayTime(d time.Duration) string {
+	minutes := int(d.Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func commandProfile(cfg *Config, commands []string) error {
+	if len(commands) > 0 {
+		if strings.ToLower(commands[0]) != "name" || len(commands) < 2 {
+			return fmt.Errorf("use profile name <name> to change your name")
+		}
+		name := strings.Join(commands[1:], " ")
+		if err := checkName("trainer name", name); err != nil {
+			return err
+		}
+		cfg.Trainer.Name = name
+		fmt.Fprintf(cfg.out, "You are now known as %s.\n", name)
+		return nil
+	}
+
+	species := make(map[string]bool)
+	for _, pokemon := range cfg.PokemonCaught {
+		species[pokemon.Species] = true
+	}
+	record := profileRecord{
+		Name:          cfg.Trainer.Name,
+		StartedAt:     cfg.Trainer.StartedAt,
+		PlaySeconds:   int(cfg.playTime().Seconds()),
+		Money:         cfg.Trainer.Money,
+		Caught:        len(cfg.PokemonCaught),
+		Species:       len(species),
+		ExploredAreas: len(cfg.ExploredAreas),
+		BattlesWon:    cfg.Trainer.BattlesWon,
+		Achievements:  len(cfg.Trainer.Achievements),
+		Badges:        cfg.earnedBadges(),
+	}
+	if handled, err := cfg.emit(record); handled {
+		return err
+	}
+
+	fmt.Fprintf(cfg.out, "Trainer: %s\n", cfg.style.Bold(record.Name))
+	fmt.Fprintf(cfg.out, "Started: %s\n", record.StartedAt.Format(time.DateOnly))
+	fmt.Fprintf(cfg.out, "Play time: %s\n", formatPlayTime(cfg.playTime()))
+	fmt.Fprintf(cfg.out, "Money: ₽%d\n", record.Money)
+	fmt.Fprintf(cfg.out, "Pokémon: %d caught, %d species\n", record.Caught, record.Species)
+	fmt.Fprintf(cfg.out, "Areas explored: %d\n", record.ExploredAreas)
+	fmt.Fprintf(cfg.out, "Battles won: %d\n", record.BattlesWon)
+	fmt.Fprintf(cfg.out, "Achievements: %d/%d\n", record.Achievements, len(achievements))
+	fmt.Fprintf(cfg.out, "Badges: %d/%d", len(record.Badges), len(badges))
+	if len(record.Badges) > 0 {
+		fmt.Fprintf(cfg.out, " (%s)", strings.Join(record.Badges, ", "))
+	}
+	fmt.Fprintln(cfg.out)
+	return nil
+}
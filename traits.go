@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/battle"
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+const (
+	// maxIV is the highest individual value a stat can have.
+	maxIV = 31
+	// shinyOdds is the 1 in N chance of a Pokémon being shiny.
+	shinyOdds = 4096
+)
+
+// natureNames lists every nature, from which caught Pokémon get theirs.
+var natureNames = []string{
+	"hardy", "lonely", "brave", "adamant", "naughty",
+	"bold", "docile", "relaxed", "impish", "lax",
+	"timid", "hasty", "serious", "jolly", "naive",
+	"modest", "mild", "quiet", "bashful", "rash",
+	"calm", "gentle", "sassy", "careful", "quirky",
+}
+
+// rollTraits gives a newly caught Pokémon what makes it unique: random
+// individual values, a nature, a gender drawn from its species' gender
+// ratio and a small chance of being shiny.
+func (cfg *Config) rollTraits(pokemon *CaughtPokemon, species *pokeapi.PokemonSpecies) error {
+	for _, name := range battle.StatNames {
+		pokemon.IVs.Set(name, cfg.rng.IntN(maxIV+1))
+	}
+
+	natureName := natureNames[cfg.rng.IntN(len(natureNames))]
+	nature, err := pokeapi.GetNature(cfg.cache, natureName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nature info for '%s': %v", natureName, err)
+	}
+	pokemon.Nature = nature.Name
+	if nature.IncreasedStat != nil && nature.DecreasedStat != nil {
+		pokemon.IncreasedStat = nature.IncreasedStat.Name
+		pokemon.DecreasedStat = nature.DecreasedStat.Name
+	}
+
+	switch {
+	case species.GenderRate < 0:
+		pokemon.Gender = "genderless"
+	case cfg.rng.IntN(8) < species.GenderRate:
+		pokemon.Gender = "female"
+	default:
+		pokemon.Gender = "male"
+	}
+
+	pokemon.Shiny = cfg.rng.IntN(shinyOdds) == 0
+	return nil
+}
+
+// natureSummary describes a Pokémon's nature and the stats it changes.
+func (p *CaughtPokemon) natureSummary() string {
+	if p.IncreasedStat == "" {
+		return p.Nature
+	}
+	return fmt.Sprintf("%s (+%s, -%s)", p.Nature, p.IncreasedStat, p.DecreasedStat)
+}
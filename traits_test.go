@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+// seedNatures caches every nature so catches work offline. Adamant raises
+// attack and lowers special attack; the rest are treated as neutral.
+func seedNatures(cfg *Config) {
+	for _, name := range natureNames {
+		body := fmt.Sprintf(`{"name": %q}`, name)
+		if name == "adamant" {
+			body = `{"name": "adamant", "increased_stat": {"name": "attack"}, "decreased_stat": {"name": "special-attack"}}`
+		}
+		cfg.cache.Add("https://pokeapi.co/api/v2/nature/"+name, []byte(body))
+	}
+}
+
+func TestRollTraits(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.reseed(7)
+	species := &pokeapi.PokemonSpecies{Name: "magnemite", GenderRate: -1}
+
+	var natures []string
+	for range 20 {
+		pokemon := &CaughtPokemon{Species: "magnemite"}
+		if err := cfg.rollTraits(pokemon, species); err != nil {
+			t.Fatalf("rollTraits returned an error: %v", err)
+		}
+		if pokemon.Gender != "genderless" {
+			t.Errorf("Expected a genderless magnemite, got %s", pokemon.Gender)
+		}
+		if pokemon.IVs.HP < 0 || pokemon.IVs.HP > maxIV || pokemon.IVs.Speed > maxIV {
+			t.Errorf("IVs out of range: %+v", pokemon.IVs)
+		}
+		natures = append(natures, pokemon.Nature)
+	}
+	if strings.Count(strings.Join(natures, " "), natures[0]) == len(natures) {
+		t.Errorf("Expected natures to vary, got %v", natures)
+	}
+
+	female := &CaughtPokemon{}
+	if err := cfg.rollTraits(female, &pokeapi.PokemonSpecies{GenderRate: 8}); err != nil {
+		t.Fatal(err)
+	}
+	if female.Gender != "female" {
+		t.Errorf("Expected an all-female species to be female, got %s", female.Gender)
+	}
+}
+
+func TestInspectShowsTraits(t *testing.T) {
+	cfg := createTestConfig()
+	var out bytes.Buffer
+	cfg.out = &out
+	info := pokeapi.PokemonInfo{ID: 25, Name: "pikachu"}
+	info.Stats = []pokeapi.PokemonStat{{BaseStat: 55}}
+	info.Stats[0].Stat.Name = "attack"
+	pikachu := givePokemon(cfg, info)
+	pikachu.Level = 50
+	pikachu.Nature, pikachu.IncreasedStat, pikachu.DecreasedStat = "adamant", "attack", "special-attack"
+	pikachu.Gender = "female"
+	pikachu.Shiny = true
+	pikachu.IVs.Attack = 31
+
+	if err := commandInspect(cfg, []string{"pikachu"}); err != nil {
+		t.Fatalf("inspect returned an error: %v", err)
+	}
+	for _, want := range []string{"Nature: adamant (+attack, -special-attack)", "Gender: female", "Shiny: yes"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the inspect output, got %q", want, out.String())
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if strings.Join(fields, " ") != "attack 55 ######........................ 82 31 0" {
+		t.Errorf("Expected attack 82 with 31 IVs and an adamant nature, got %q", lines[len(lines)-1])
+	}
+}
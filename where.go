@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/OttScott/pokedexcli/internal/pokeapi"
+)
+
+func commandWhere(cfg *Config, commands []string) error {
+	args, flags, err := parseArgs(commands)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("where command requires a Pokémon name as an argument")
+	}
+
+	pokemonName := args[0]
+	version := flags["version"]
+	encounters, err := pokeapi.GetPokemonEncounters(cfg.cache, pokemonName, version)
+	if err != nil {
+		return fmt.Errorf("failed to fetch encounters for '%s': %v", pokemonName, err)
+	}
+	if encounters == nil {
+		encounters = []pokeapi.EncounterSummary{}
+	}
+	if handled, err := cfg.emit(encounters); handled {
+		return err
+	}
+
+	if len(encounters) == 0 {
+		if version != "" {
+			fmt.Fprintf(cfg.out, "%s can't be found in the wild in %s.\n", pokemonName, version)
+		} else {
+			fmt.Fprintf(cfg.out, "%s can't be found in the wild.\n", pokemonName)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(cfg.out, "%s can be found in:\n", pokemonName)
+	printEncounterTable(cfg.out, encounters, "LOCATION AREA", func(e pokeapi.EncounterSummary) string { return e.LocationArea })
+	return nil
+}
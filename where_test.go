@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCommandWhere(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.cache.Add("https://pokeapi.co/api/v2/pokemon/pikachu/encounters", []byte(`[
+		{"location_area": {"name": "viridian-forest-area", "url": ""}, "version_details": [
+			{"version": {"name": "yellow", "url": ""}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 5, "chance": 5, "condition_values": [], "method": {"name": "walk", "url": ""}}
+			]}
+		]}
+	]`))
+
+	if err := commandWhere(cfg, []string{"pikachu"}); err != nil {
+		t.Errorf("where returned an error: %v", err)
+	}
+	if err := commandWhere(cfg, []string{"pikachu", "--version", "red"}); err != nil {
+		t.Errorf("where with a version filter returned an error: %v", err)
+	}
+}
+
+func TestCommandWhereRequiresName(t *testing.T) {
+	cfg := createTestConfig()
+
+	if err := commandWhere(cfg, nil); err == nil {
+		t.Error("Expected an error when no Pokémon is given")
+	}
+}